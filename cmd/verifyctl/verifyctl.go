@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/ibm-verify/verifyctl/pkg/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/config"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
 )
 
 func main() {
@@ -30,14 +32,19 @@ func main() {
 		}
 	}()
 
+	// route all requests, including those made by the SDK, through the tenant network settings
+	xhttp.InstallDefaultTransport()
+
 	ctx, err := contextx.NewContextWithVerifyContext(context.Background(), logger)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
+	// an invalid file is reported by the commands, so that it can be diagnosed
+	var invalidConfigErr *config.InvalidConfigError
 	config, err := config.NewCLIConfig().LoadFromFile()
-	if err != nil {
+	if err != nil && !errors.As(err, &invalidConfigErr) {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
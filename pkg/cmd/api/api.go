@@ -0,0 +1,335 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/api"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	usage         = "api <path> [flags]"
+	messagePrefix = "API"
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Make an authenticated request to any API of your tenant.
+
+The path is relative to the tenant, such as "/v2.0/Users" or "/v1.0/applications", and may include a
+query string. The access token of the current context is added to the request and renewed if it has
+expired. The credential used can be selected with the global "--credential" flag.
+
+The request body is read from a JSON or YAML file using "-f", or from stdin using "-f -", and is sent
+as JSON. Fields can also be set with "--field key=value". Values are sent as JSON numbers, booleans
+or null when they are valid as such, and "@path" reads the value from a file. For GET and DELETE
+requests, fields are added to the query string instead. The method defaults to GET, or POST when a
+body or fields are provided.
+
+Lists can be fetched in full using "--paginate". SCIM lists, such as users and groups, are followed
+using "startIndex" and "count". Other lists are followed using "page" and "limit" until a page has
+fewer items than the limit. The items of all the pages are merged into a single response.
+
+The response is printed as JSON or YAML. If the request fails, the response is printed and the
+command exits with a non-zero code.`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# List the applications
+		verifyctl api /v1.0/applications
+
+		# Get every user, following the SCIM pagination, as YAML
+		verifyctl api "/v2.0/Users?count=100" --paginate -o=yaml
+
+		# Search with query parameters
+		verifyctl api /v2.0/Groups -F filter='displayName eq "admin"'
+
+		# Create a resource from a file
+		verifyctl api /v1.0/authnmethods/password/policies -f=policy.yaml
+
+		# Update a resource with fields
+		verifyctl api /v1.0/branding/themes/default -X=PATCH -F enabled=true -F description=@description.txt
+
+		# Delete a resource
+		verifyctl api /v2.0/Users/612000XYZ -X=DELETE`))
+)
+
+type options struct {
+	method   string
+	file     string
+	fields   []string
+	headers  []string
+	paginate bool
+	output   string
+
+	path string
+
+	config *config.CLIConfig
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &options{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Make an authenticated request to any API of your tenant."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Args:                  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.method, "method", "X", "", i18n.TranslateWithArgs("HTTP method of the request. The values supported are %s. Default: GET, or POST when a body or fields are provided.", strings.Join(api.Methods, ", ")))
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to a JSON or YAML file with the request body, or '-' to read it from stdin."))
+	cmd.Flags().StringArrayVarP(&o.fields, "field", "F", nil, i18n.Translate("Field of the request body in the format 'key=value', or a query parameter for GET and DELETE requests. Use '@path' as the value to read it from a file. Can be repeated."))
+	cmd.Flags().StringArrayVarP(&o.headers, "header", "H", nil, i18n.Translate("Header of the request in the format 'key:value'. Can be repeated."))
+	cmd.Flags().BoolVar(&o.paginate, "paginate", false, i18n.Translate("Get every page of a list and merge the items into a single response. Only GET requests can be paginated."))
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the output. The values supported are 'json' and 'yaml'. Default: 'json'."))
+}
+
+func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	o.path = args[0]
+	o.method = strings.ToUpper(o.method)
+	if len(o.method) == 0 {
+		o.method = http.MethodGet
+		if len(o.file) > 0 || len(o.fields) > 0 {
+			o.method = http.MethodPost
+		}
+	}
+
+	if len(o.output) == 0 {
+		o.output = "json"
+	}
+
+	return nil
+}
+
+func (o *options) Validate(cmd *cobra.Command, args []string) error {
+	if !slices.Contains(api.Methods, o.method) {
+		return errorsx.G11NError("unsupported method '%s'. Use one of: %s", o.method, strings.Join(api.Methods, ", "))
+	}
+
+	if o.output != "json" && o.output != "yaml" {
+		return errorsx.G11NError("unsupported output format '%s'. Use 'json' or 'yaml'.", o.output)
+	}
+
+	if o.paginate && o.method != http.MethodGet {
+		return errorsx.G11NError("Only GET requests can be paginated.")
+	}
+
+	if len(o.file) > 0 && !hasBody(o.method) {
+		return errorsx.G11NError("A request body cannot be sent with %s.", o.method)
+	}
+
+	return nil
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	auth, err := o.config.SetAuthToContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := o.buildRequest(cmd)
+	if err != nil {
+		return err
+	}
+
+	c := api.NewAPIClient()
+	if o.paginate {
+		response, merged, err := c.Paginate(ctx, auth, req)
+		if err != nil {
+			return err
+		}
+
+		if merged == nil {
+			return o.writeResponse(cmd, response.StatusCode, response.Body)
+		}
+
+		o.write(cmd, merged)
+		return nil
+	}
+
+	response, err := c.Do(ctx, auth, req)
+	if err != nil {
+		return err
+	}
+
+	return o.writeResponse(cmd, response.StatusCode, response.Body)
+}
+
+// buildRequest reads the body and applies the fields and headers.
+func (o *options) buildRequest(cmd *cobra.Command) (*api.Request, error) {
+	vc := contextx.GetVerifyContext(cmd.Context())
+	req := &api.Request{
+		Method:  o.method,
+		Path:    o.path,
+		Headers: http.Header{},
+	}
+
+	for _, h := range o.headers {
+		key, value, ok := strings.Cut(h, ":")
+		if !ok || len(strings.TrimSpace(key)) == 0 {
+			return nil, errorsx.G11NError("invalid header '%s'. Use the format 'key:value'.", h)
+		}
+
+		req.Headers.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+
+	var body interface{}
+	if len(o.file) > 0 {
+		b, err := readFile(o.file)
+		if err != nil {
+			vc.Logger.Errorf("unable to read file; filename=%s, err=%v", o.file, err)
+			return nil, err
+		}
+
+		// YAML is a superset of JSON, so both are decoded the same way
+		if err := yaml.Unmarshal(b, &body); err != nil {
+			return nil, errorsx.G11NError("unable to parse the request body; err=%v", err)
+		}
+	}
+
+	if len(o.fields) > 0 {
+		query := url.Values{}
+		fields := map[string]interface{}{}
+		if m, ok := body.(map[string]interface{}); ok {
+			fields = m
+		} else if body != nil {
+			return nil, errorsx.G11NError("fields can only be added to a request body that is an object")
+		}
+
+		for _, f := range o.fields {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok || len(key) == 0 {
+				return nil, errorsx.G11NError("invalid field '%s'. Use the format 'key=value'.", f)
+			}
+
+			if path, ok := strings.CutPrefix(value, "@"); ok {
+				b, err := readFile(path)
+				if err != nil {
+					return nil, err
+				}
+
+				value = string(b)
+			}
+
+			if !hasBody(o.method) {
+				query.Add(key, value)
+				continue
+			}
+
+			fields[key] = fieldValue(value)
+		}
+
+		if hasBody(o.method) {
+			body = fields
+		} else {
+			sep := "?"
+			if strings.Contains(req.Path, "?") {
+				sep = "&"
+			}
+
+			req.Path += sep + query.Encode()
+		}
+	}
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, errorsx.G11NError("unable to encode the request body; err=%v", err)
+		}
+
+		req.Body = b
+	}
+
+	return req, nil
+}
+
+// writeResponse prints the response body. Failed requests are reported as errors after
+// the body is printed.
+func (o *options) writeResponse(cmd *cobra.Command, statusCode int, body []byte) error {
+	if len(body) > 0 {
+		var obj interface{}
+		if err := json.Unmarshal(body, &obj); err == nil {
+			o.write(cmd, obj)
+		} else {
+			cmdutil.WriteAsBinary(cmd, body, cmd.OutOrStdout())
+			if body[len(body)-1] != '\n' {
+				cmdutil.WriteString(cmd, "")
+			}
+		}
+	}
+
+	if statusCode < 200 || statusCode > 299 {
+		return errorsx.G11NError("The request failed with the status code %d.", statusCode)
+	}
+
+	return nil
+}
+
+func (o *options) write(cmd *cobra.Command, obj interface{}) {
+	if o.output == "yaml" {
+		cmdutil.WriteAsYAML(cmd, obj, cmd.OutOrStdout())
+		return
+	}
+
+	cmdutil.WriteAsJSON(cmd, obj, cmd.OutOrStdout())
+	cmdutil.WriteString(cmd, "")
+}
+
+func readFile(file string) ([]byte, error) {
+	if file == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(file)
+}
+
+// fieldValue keeps numbers, booleans and null as such. Other values are strings.
+func fieldValue(value string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err == nil {
+		switch v.(type) {
+		case float64, bool, nil:
+			return v
+		}
+	}
+
+	return value
+}
+
+func hasBody(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
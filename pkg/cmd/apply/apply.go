@@ -0,0 +1,197 @@
+package apply
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	"github.com/ibm-verify/verifyctl/pkg/module/kinds"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	usage         = "apply -f=FILENAME [options]"
+	messagePrefix = "Apply"
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Create or update Verify resources from files.
+
+Each resource is looked up on the tenant by its name, which is the field of the data that is unique for
+its kind: "userName" for users, "displayName" for groups, "name" for attributes and access policies,
+"instanceName" for identity sources and "clientName" for API clients. The resource is created if it does
+not exist, and updated if any of the fields in the file differs from the tenant. Fields that are not in
+the file are not compared.
+
+The hash of the data applied to attributes and API clients is stored on the tenant, as the
+"verifyctl.lastApplied" custom property of the resource: in "customProperties" for attributes and in
+"additionalProperties" for API clients. Such a resource is updated when its data has changed since it was
+last applied, even if the fields of the file match the tenant, and 'export' writes the hash as the
+"lastApplied" metadata. The other kinds have no field to store it and are only compared with the tenant.
+Resources that do not differ from their file are reported as unchanged and are not updated.
+
+Files are read as with 'create'. A file may contain several resources and a directory may be passed, in
+which case a summary of the resources that succeeded and failed is printed at the end.
+
+Group members are named by their "userName" and the user passwords are only set when the user is
+created.`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# Create or update the users in a file
+		verifyctl apply -f=./users.yaml
+
+		# Apply all the resources in a directory and its subdirectories
+		verifyctl apply -f=./tenant --recursive`))
+)
+
+type options struct {
+	file      string
+	recursive bool
+
+	config *config.CLIConfig
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &options{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Create or update Verify resources from files."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to the file or directory that contains the resources, or '-' to read them from stdin. JSON and YAML formats are supported and the files are expected to be named with the appropriate extension: json, yml or yaml."))
+	cmd.Flags().BoolVarP(&o.recursive, "recursive", "R", false, i18n.Translate("Read the files in the subdirectories of the directory passed to 'file'."))
+}
+
+func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *options) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.file) == 0 {
+		return errorsx.G11NError("'file' option is required")
+	}
+
+	return nil
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	resourceObjects, err := resource.LoadResourceObjects(cmd, o.file, o.recursive)
+	if err != nil {
+		return err
+	}
+
+	return resource.RunEach(cmd, resourceObjects, func(resourceObject *resource.SourcedResourceObject) error {
+		return o.applyResource(cmd, resourceObject.ResourceObject)
+	})
+}
+
+// applyResource creates the resource if it does not exist and updates it if it differs.
+func (o *options) applyResource(cmd *cobra.Command, resourceObject *resource.ResourceObject) error {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+	if len(resourceObject.Kind) == 0 {
+		return errorsx.G11NError("No 'kind' defined. Resource type cannot be identified.")
+	}
+
+	kind, ok := kinds.ForKind(strings.TrimPrefix(resourceObject.Kind, resource.ResourceTypePrefix))
+	if !ok {
+		return errorsx.G11NError("The kind '%s' cannot be applied.", resourceObject.Kind)
+	}
+
+	data, err := resourceObject.DataMap()
+	if err != nil {
+		return err
+	}
+
+	name := kind.NameOf(data)
+	if len(name) == 0 {
+		return errorsx.G11NError("The '%s' field is required to apply the resource.", kind.Key)
+	}
+
+	hash, err := kind.Hash(data)
+	if err != nil {
+		return err
+	}
+
+	auth, err := entitlements.SetAuthToContext(ctx, o.config, entitlements.VerbReplace, kind.Resource)
+	if err != nil {
+		return err
+	}
+
+	live, err := kind.Get(ctx, auth, name)
+	if err != nil {
+		vc.Logger.Errorf("unable to get the resource; kind=%s, name=%s, err=%v", resourceObject.Kind, name, err)
+		return err
+	}
+
+	if live == nil {
+		data, err := kind.WithLastApplied(data, hash)
+		if err != nil {
+			return err
+		}
+
+		uri, err := kind.Create(ctx, auth, data)
+		if err != nil {
+			vc.Logger.Errorf("unable to create the resource; kind=%s, name=%s, err=%v", resourceObject.Kind, name, err)
+			return err
+		}
+
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("%s '%s' created: %s", resourceObject.Kind, name, uri))
+		return nil
+	}
+
+	desired, err := kind.Resolve(ctx, auth, data)
+	if err != nil {
+		return err
+	}
+
+	// a hash that differs from the one on the tenant is a change of its own, so that the
+	// data is written again when the file has changed
+	desired, err = kind.WithLastApplied(desired, hash)
+	if err != nil {
+		return err
+	}
+
+	if !kind.Differs(live, desired) {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("%s '%s' unchanged.", resourceObject.Kind, name))
+		return nil
+	}
+
+	if err := kind.Update(ctx, auth, live, desired); err != nil {
+		vc.Logger.Errorf("unable to update the resource; kind=%s, name=%s, err=%v", resourceObject.Kind, name, err)
+		return err
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("%s '%s' updated.", resourceObject.Kind, name))
+	return nil
+}
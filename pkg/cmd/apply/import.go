@@ -0,0 +1,373 @@
+package apply
+
+import (
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	"github.com/ibm-verify/verifyctl/pkg/module/kinds"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	importUsage         = "import --dir=DIRECTORY [options]"
+	importMessagePrefix = "Import"
+)
+
+var (
+	importLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(importMessagePrefix, `
+		Import the resources of a directory to the tenant, such as a directory written by 'export'.
+
+The files of the directory and its subdirectories are read as with 'create', and each resource is created
+or updated as with 'apply'. The resources are applied after the resources they reference, such as the
+users and groups that are members of a group and the users that own it. Resources that do not reference
+each other are applied in this order: attributes, users, groups, identity sources, API clients and access
+policies.
+
+Before any resource is created or updated, the directory is checked and the command fails if:
+  - a resource has an unsupported kind or no name, or is defined more than once;
+  - resources reference each other in a cycle;
+  - a resource references one that is neither in the directory nor on the tenant.
+
+The import stops at the first resource that fails. Running the command again resumes the import: the
+resources applied by the previous run do not differ from their files and are reported as unchanged.
+
+Themes are not imported. Use 'set theme' to update them.`))
+
+	importExamples = templates.Examples(cmdutil.TranslateExamples(importMessagePrefix, `
+		# Check the resources of a directory and print the order they are applied in
+		verifyctl import --dir=./tenant --dry-run
+
+		# Import the resources of a directory, or resume a previous import that failed
+		verifyctl import --dir=./tenant`))
+)
+
+type importOptions struct {
+	dir    string
+	dryRun bool
+
+	config *config.CLIConfig
+}
+
+// importNode is a resource along with the resources it references in the directory.
+type importNode struct {
+	object *resource.SourcedResourceObject
+	kind   *kinds.Kind
+	name   string
+
+	// order is the position of the kind in the registry
+	order int
+
+	requires   []*importNode
+	dependents []*importNode
+}
+
+// importReference is a reference to a resource that is not in the directory.
+type importReference struct {
+	from *importNode
+	to   *kinds.Reference
+}
+
+func NewImportCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &importOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   importUsage,
+		Short:                 cmdutil.TranslateShortDesc(importMessagePrefix, "Import the resources of a directory to the tenant, in the order of their references."),
+		Long:                  importLongDesc,
+		Example:               importExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *importOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.dir, "dir", "", i18n.Translate("Path to the directory that contains the resources. Its subdirectories are read as well."))
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", false, i18n.Translate("Check the resources and print the order they would be applied in, without creating or updating any."))
+}
+
+func (o *importOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *importOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.dir) == 0 {
+		return errorsx.G11NError("'dir' option is required")
+	}
+
+	return nil
+}
+
+func (o *importOptions) Run(cmd *cobra.Command, args []string) error {
+	resourceObjects, err := resource.LoadResourceObjects(cmd, o.dir, true)
+	if err != nil {
+		return err
+	}
+
+	// every problem is reported before the tenant is changed
+	nodes, missing, problems := buildImportGraph(resourceObjects)
+	ordered, cycles := sortImportGraph(nodes)
+	for _, cycle := range cycles {
+		problems = append(problems, i18n.TranslateWithArgs("The resources reference each other in a cycle: %s", describeCycle(cycle)))
+	}
+
+	problems = append(problems, o.checkReferences(cmd, missing)...)
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			_, _ = io.WriteString(cmd.ErrOrStderr(), "  - "+problem+"\n")
+		}
+
+		return errorsx.G11NError("%d problems found in '%s'. No resources were imported.", len(problems), o.dir)
+	}
+
+	if o.dryRun {
+		for i, node := range ordered {
+			cmdutil.WriteString(cmd, strconv.Itoa(i+1)+"\t"+describeNode(node))
+		}
+
+		return nil
+	}
+
+	a := &options{
+		config: o.config,
+	}
+
+	imported := 0
+	var failed *importNode
+	for _, node := range ordered {
+		if err = a.applyResource(cmd, node.object.ResourceObject); err != nil {
+			failed = node
+			break
+		}
+
+		imported++
+	}
+
+	if failed != nil {
+		_, _ = io.WriteString(cmd.ErrOrStderr(), i18n.TranslateWithArgs("%d of %d resources were imported. Run the command again to resume the import.", imported, len(ordered))+"\n")
+		return errorsx.G11NError("Unable to import %s; err=%v", describeNode(failed), err)
+	}
+
+	cmdutil.WriteString(cmd, "")
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("%d resources imported.", imported))
+	return nil
+}
+
+// checkReferences returns a problem for each reference that is not on the tenant.
+func (o *importOptions) checkReferences(cmd *cobra.Command, missing []*importReference) []string {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+	problems := []string{}
+	found := map[string]bool{}
+	for _, ref := range missing {
+		key := resourceKey(ref.to.Kind.Name, ref.to.Name)
+		exists, ok := found[key]
+		if !ok {
+			auth, err := entitlements.SetAuthToContext(ctx, o.config, entitlements.VerbGet, ref.to.Kind.Resource)
+			if err != nil {
+				problems = append(problems, i18n.TranslateWithArgs("Unable to check the reference of %s to %s '%s'; err=%v", describeNode(ref.from), ref.to.Kind.Name, ref.to.Name, err))
+				continue
+			}
+
+			live, err := ref.to.Kind.Get(ctx, auth, ref.to.Name)
+			if err != nil {
+				vc.Logger.Errorf("unable to get the resource; kind=%s, name=%s, err=%v", ref.to.Kind.Name, ref.to.Name, err)
+				problems = append(problems, i18n.TranslateWithArgs("Unable to check the reference of %s to %s '%s'; err=%v", describeNode(ref.from), ref.to.Kind.Name, ref.to.Name, err))
+				continue
+			}
+
+			exists = live != nil
+			found[key] = exists
+		}
+
+		if !exists {
+			problems = append(problems, i18n.TranslateWithArgs("%s references the %s '%s', which is neither in the directory nor on the tenant.", describeNode(ref.from), ref.to.Kind.Name, ref.to.Name))
+		}
+	}
+
+	return problems
+}
+
+// resourceKey identifies a resource by its kind and name.
+func resourceKey(kind string, name string) string {
+	return kind + "/" + name
+}
+
+// buildImportGraph returns a node for each resource, linked to the resources it references
+// in the directory, along with the references to resources that are not in the directory
+// and the problems found.
+func buildImportGraph(resourceObjects []*resource.SourcedResourceObject) ([]*importNode, []*importReference, []string) {
+	nodes := []*importNode{}
+	byKey := map[string]*importNode{}
+	problems := []string{}
+	for _, resourceObject := range resourceObjects {
+		kind, ok := kinds.ForKind(strings.TrimPrefix(resourceObject.Kind, resource.ResourceTypePrefix))
+		if !ok {
+			problems = append(problems, i18n.TranslateWithArgs("The kind '%s' of the resource in '%s' cannot be imported.", resourceObject.Kind, resourceObject.Source))
+			continue
+		}
+
+		data, err := resourceObject.DataMap()
+		if err != nil {
+			problems = append(problems, i18n.TranslateWithArgs("The resource in '%s' is invalid; err=%v", resourceObject.Source, err))
+			continue
+		}
+
+		node := &importNode{
+			object: resourceObject,
+			kind:   kind,
+			name:   kind.NameOf(data),
+			order:  slices.Index(kinds.All(), kind),
+		}
+
+		if len(node.name) == 0 {
+			problems = append(problems, i18n.TranslateWithArgs("The '%s' field of the resource in '%s' is required to import it.", kind.Key, resourceObject.Source))
+			continue
+		}
+
+		// the data is hashed when it is applied, so it is checked before anything is applied
+		if _, err = kind.Hash(data); err != nil {
+			problems = append(problems, i18n.TranslateWithArgs("The resource in '%s' is invalid; err=%v", resourceObject.Source, err))
+			continue
+		}
+
+		key := resourceKey(kind.Name, node.name)
+		if other, ok := byKey[key]; ok {
+			problems = append(problems, i18n.TranslateWithArgs("%s is also defined in '%s'.", describeNode(node), other.object.Source))
+			continue
+		}
+
+		byKey[key] = node
+		nodes = append(nodes, node)
+	}
+
+	missing := []*importReference{}
+	for _, node := range nodes {
+		data, _ := node.object.DataMap()
+		for _, ref := range node.kind.References(data) {
+			required, ok := byKey[resourceKey(ref.Kind.Name, ref.Name)]
+			if !ok {
+				missing = append(missing, &importReference{from: node, to: ref})
+				continue
+			}
+
+			// a resource that references itself cannot be applied, and is reported as a cycle
+			if slices.Contains(node.requires, required) {
+				continue
+			}
+
+			node.requires = append(node.requires, required)
+			required.dependents = append(required.dependents, node)
+		}
+	}
+
+	return nodes, missing, problems
+}
+
+// sortImportGraph returns the nodes that can be applied, sorted so that each node comes
+// after the nodes it requires, and the cycles that prevent the other nodes from being
+// applied. Among the nodes that are ready, the order of the kinds in the registry and
+// then the names are used, so that the order is stable.
+func sortImportGraph(nodes []*importNode) ([]*importNode, [][]*importNode) {
+	pending := map[*importNode]int{}
+	ready := []*importNode{}
+	for _, node := range nodes {
+		pending[node] = len(node.requires)
+		if len(node.requires) == 0 {
+			ready = append(ready, node)
+		}
+	}
+
+	slices.SortFunc(ready, compareNodes)
+	ordered := []*importNode{}
+	for len(ready) > 0 {
+		node := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, node)
+		for _, dependent := range node.dependents {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				i, _ := slices.BinarySearchFunc(ready, dependent, compareNodes)
+				ready = slices.Insert(ready, i, dependent)
+			}
+		}
+	}
+
+	// each node left requires another node left, so following the requirements from any
+	// of them ends in a cycle
+	cycles := [][]*importNode{}
+	walked := map[*importNode]bool{}
+	for _, node := range nodes {
+		path := []*importNode{}
+		index := map[*importNode]int{}
+		for node != nil && pending[node] > 0 && !walked[node] {
+			walked[node] = true
+			index[node] = len(path)
+			path = append(path, node)
+
+			next := node
+			node = nil
+			for _, required := range next.requires {
+				if pending[required] > 0 {
+					node = required
+					break
+				}
+			}
+		}
+
+		if i, ok := index[node]; ok && node != nil {
+			cycles = append(cycles, path[i:])
+		}
+	}
+
+	return ordered, cycles
+}
+
+func compareNodes(a *importNode, b *importNode) int {
+	if a.order != b.order {
+		return a.order - b.order
+	}
+
+	if c := strings.Compare(a.name, b.name); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.object.Source, b.object.Source)
+}
+
+func describeNode(node *importNode) string {
+	return node.object.Kind + " '" + node.name + "' (" + node.object.Source + ")"
+}
+
+func describeCycle(cycle []*importNode) string {
+	parts := []string{}
+	for _, node := range cycle {
+		parts = append(parts, describeNode(node))
+	}
+
+	return strings.Join(append(parts, describeNode(cycle[0])), " -> ")
+}
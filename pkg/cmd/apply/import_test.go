@@ -0,0 +1,296 @@
+package apply
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+)
+
+func TestBuildImportGraph(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*resource.SourcedResourceObject
+
+		wantNodes    []string
+		wantRequires map[string][]string
+		wantMissing  []string
+		wantProblems int
+	}{
+		{
+			name: "links groups to their members",
+			resources: []*resource.SourcedResourceObject{
+				testResource("Group", "devs.yaml", map[string]interface{}{
+					"displayName": "devs",
+					"members": []interface{}{
+						map[string]interface{}{"value": "bob"},
+						map[string]interface{}{"value": "carol"},
+						map[string]interface{}{"value": "bob"},
+					},
+				}),
+				testResource("User", "bob.yaml", map[string]interface{}{"userName": "bob"}),
+			},
+			wantNodes:    []string{"Group/devs", "User/bob"},
+			wantRequires: map[string][]string{"Group/devs": {"User/bob"}},
+			wantMissing:  []string{"Group/devs -> User/carol"},
+		},
+		{
+			name: "links groups to their member groups and owners",
+			resources: []*resource.SourcedResourceObject{
+				testResource("Group", "admins.yaml", map[string]interface{}{
+					"displayName": "admins",
+					"members": []interface{}{
+						map[string]interface{}{"type": "Group", "value": "devs"},
+						map[string]interface{}{"type": "group", "value": "ops"},
+					},
+					"urn:ietf:params:scim:schemas:extension:ibm:2.0:Group": map[string]interface{}{
+						"owners": []interface{}{
+							map[string]interface{}{"value": "bob"},
+						},
+					},
+				}),
+				testGroup("devs", "bob"),
+				testUser("bob"),
+			},
+			wantNodes: []string{"Group/admins", "Group/devs", "User/bob"},
+			wantRequires: map[string][]string{
+				"Group/admins": {"Group/devs", "User/bob"},
+				"Group/devs":   {"User/bob"},
+			},
+			wantMissing: []string{"Group/admins -> Group/ops"},
+		},
+		{
+			name: "reports invalid and duplicate resources",
+			resources: []*resource.SourcedResourceObject{
+				testResource("User", "bob.yaml", map[string]interface{}{"userName": "bob"}),
+				testResource("User", "users.yaml#2", map[string]interface{}{"userName": "bob"}),
+				testResource("User", "noname.yaml", map[string]interface{}{"title": "Manager"}),
+				testResource("Theme", "theme.yaml", map[string]interface{}{"name": "default"}),
+				{ResourceObject: &resource.ResourceObject{Kind: resource.ResourceTypePrefix + "User"}, Source: "nodata.yaml"},
+			},
+			wantNodes:    []string{"User/bob"},
+			wantProblems: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, missing, problems := buildImportGraph(tt.resources)
+
+			got := []string{}
+			requires := map[string][]string{}
+			for _, node := range nodes {
+				got = append(got, nodeKey(node))
+				for _, required := range node.requires {
+					requires[nodeKey(node)] = append(requires[nodeKey(node)], nodeKey(required))
+				}
+			}
+
+			if !slices.Equal(got, tt.wantNodes) {
+				t.Errorf("buildImportGraph() nodes = %v, want %v", got, tt.wantNodes)
+			}
+
+			for key, want := range tt.wantRequires {
+				if !slices.Equal(requires[key], want) {
+					t.Errorf("buildImportGraph() %s requires %v, want %v", key, requires[key], want)
+				}
+			}
+
+			gotMissing := []string{}
+			for _, ref := range missing {
+				gotMissing = append(gotMissing, nodeKey(ref.from)+" -> "+ref.to.Kind.Name+"/"+ref.to.Name)
+			}
+
+			if !slices.Equal(gotMissing, tt.wantMissing) {
+				t.Errorf("buildImportGraph() missing = %v, want %v", gotMissing, tt.wantMissing)
+			}
+
+			if len(problems) != tt.wantProblems {
+				t.Errorf("buildImportGraph() returned %d problems, want %d: %v", len(problems), tt.wantProblems, problems)
+			}
+		})
+	}
+}
+
+func TestSortImportGraph(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// resources are in the order they are read from the directory
+		resources []*resource.SourcedResourceObject
+
+		wantOrdered []string
+		wantCycles  []string
+	}{
+		{
+			name: "orders by kind and then by name",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("b"),
+				testUser("carol"),
+				testResource("Attribute", "z.yaml", map[string]interface{}{"name": "z"}),
+				testUser("bob"),
+				testGroup("a"),
+			},
+			wantOrdered: []string{"Attribute/z", "User/bob", "User/carol", "Group/a", "Group/b"},
+		},
+		{
+			name: "applies a member group defined later first",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("all", "group:eng", "group:ops"),
+				testGroup("eng", "bob"),
+				testGroup("ops", "group:eng"),
+				testUser("bob"),
+			},
+			wantOrdered: []string{"User/bob", "Group/eng", "Group/ops", "Group/all"},
+		},
+		{
+			name: "applies the owners of a group first",
+			resources: []*resource.SourcedResourceObject{
+				testResource("Group", "admins.yaml", map[string]interface{}{
+					"displayName": "admins",
+					"urn:ietf:params:scim:schemas:extension:ibm:2.0:Group": map[string]interface{}{
+						"owners": []interface{}{
+							map[string]interface{}{"value": "zoe"},
+						},
+					},
+				}),
+				testGroup("devs", "group:admins"),
+				testUser("zoe"),
+				testUser("al"),
+			},
+			wantOrdered: []string{"User/al", "User/zoe", "Group/admins", "Group/devs"},
+		},
+		{
+			name: "reports a group that is a member of itself",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("a", "group:a", "bob"),
+				testGroup("b"),
+				testUser("bob"),
+			},
+			wantOrdered: []string{"User/bob", "Group/b"},
+			wantCycles:  []string{"Group/a -> Group/a"},
+		},
+		{
+			name: "reports a cycle",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("a", "group:b"),
+				testGroup("b", "group:a"),
+				testGroup("c"),
+			},
+			wantOrdered: []string{"Group/c"},
+			wantCycles:  []string{"Group/a -> Group/b -> Group/a"},
+		},
+		{
+			name: "reports the cycle without its dangling tail",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("tail", "group:a"),
+				testGroup("a", "group:b"),
+				testGroup("b", "group:c"),
+				testGroup("c", "group:b"),
+			},
+			wantOrdered: []string{},
+			wantCycles:  []string{"Group/b -> Group/c -> Group/b"},
+		},
+		{
+			name: "reports a cycle reached from a walked tail once",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("c", "group:b"),
+				testGroup("b", "group:c"),
+				testGroup("x", "group:b"),
+				testGroup("y", "group:x"),
+			},
+			wantOrdered: []string{},
+			wantCycles:  []string{"Group/c -> Group/b -> Group/c"},
+		},
+		{
+			name: "reports separate cycles",
+			resources: []*resource.SourcedResourceObject{
+				testGroup("a", "group:b"),
+				testGroup("b", "group:a"),
+				testGroup("c", "group:d"),
+				testGroup("d", "group:c"),
+			},
+			wantOrdered: []string{},
+			wantCycles:  []string{"Group/a -> Group/b -> Group/a", "Group/c -> Group/d -> Group/c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, missing, problems := buildImportGraph(tt.resources)
+			if len(missing) > 0 || len(problems) > 0 {
+				t.Fatalf("buildImportGraph() missing = %v, problems = %v", missing, problems)
+			}
+
+			ordered, cycles := sortImportGraph(nodes)
+
+			got := []string{}
+			for _, node := range ordered {
+				got = append(got, nodeKey(node))
+			}
+
+			if !slices.Equal(got, tt.wantOrdered) {
+				t.Errorf("sortImportGraph() ordered = %v, want %v", got, tt.wantOrdered)
+			}
+
+			gotCycles := []string{}
+			for _, cycle := range cycles {
+				keys := []string{}
+				for _, node := range append(cycle, cycle[0]) {
+					keys = append(keys, nodeKey(node))
+				}
+
+				gotCycles = append(gotCycles, strings.Join(keys, " -> "))
+			}
+
+			if !slices.Equal(gotCycles, tt.wantCycles) {
+				t.Errorf("sortImportGraph() cycles = %v, want %v", gotCycles, tt.wantCycles)
+			}
+		})
+	}
+}
+
+func testResource(kind string, source string, data map[string]interface{}) *resource.SourcedResourceObject {
+	obj := &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + kind,
+		APIVersion: "2.0",
+	}
+
+	if data != nil {
+		obj.Data = data
+	}
+
+	return &resource.SourcedResourceObject{
+		ResourceObject: obj,
+		Source:         source,
+	}
+}
+
+func nodeKey(node *importNode) string {
+	return node.kind.Name + "/" + node.name
+}
+
+func testUser(userName string) *resource.SourcedResourceObject {
+	return testResource("User", userName+".yaml", map[string]interface{}{"userName": userName})
+}
+
+// testGroup returns a group with the members, where "group:name" is a member group and
+// any other member is a user.
+func testGroup(displayName string, members ...string) *resource.SourcedResourceObject {
+	data := map[string]interface{}{"displayName": displayName}
+	if len(members) > 0 {
+		list := []interface{}{}
+		for _, member := range members {
+			if name, ok := strings.CutPrefix(member, "group:"); ok {
+				list = append(list, map[string]interface{}{"type": "group", "value": name})
+			} else {
+				list = append(list, map[string]interface{}{"value": member})
+			}
+		}
+
+		data["members"] = list
+	}
+
+	return testResource("Group", displayName+".yaml", data)
+}
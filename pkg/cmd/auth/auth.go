@@ -4,11 +4,13 @@ import (
 	"io"
 	"net/url"
 
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
 	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -21,18 +23,57 @@ const (
 
 var (
 	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
-		Log in to your tenant and save the connection for subsequent use until the security token expires.
+		Log in to your tenant and save the connection for subsequent use.
 		
 First-time users of the client should run this command to connect to a tenant to establish an authorized session. 
 The issued OAuth 2.0 security token is saved to the configuration file at your home directory under ".verify/config".
 
+The token is renewed automatically before it expires. User sessions are renewed with the refresh token,
+if one is issued, and API client sessions request a new token using the saved client credentials.
+
+The client secret and the private key of an API client are only saved if the credential store is
+encrypted (see 'config set-credential-store') or if "--save-secret" is used, in which case they are
+written in the clear to the configuration file, readable only by your user. Otherwise, login again
+when the token expires. A private key read from a file using "@path" is saved as the path.
+
 There are two methods to generate the authorized token, based on flags:
 		
   - As a user providing credentials
   - As an API client
-		
+
 In both cases, an OAuth token is generated with specific entitlements.
 
+Users log in with the OAuth 2.0 Device Flow by default. The verification page is shown as a link and a
+QR code that can be scanned with a phone, along with the code to confirm on the page. Use "--open-browser"
+to open the page in the default browser. The login can be cancelled with Ctrl-C.
+
+Set "flow: authorization_code" in the auth resource file to log in with the browser using the authorization
+code flow with PKCE instead. The browser is redirected to a listener that verifyctl starts on the local machine.
+
+API client secrets can be kept out of the auth resource file with an "exec" section. The command is run
+whenever a new token is needed and prints a JSON object to stdout with either "token" and "expiry" (or
+"expires_in"), or "client_id" with "client_secret" or "private_key". Tokens are cached until they expire
+and client credentials returned by the command are never saved.
+
+The "network" section configures how the tenant is reached: "proxy", "caFile" for a private CA bundle,
+"clientCert" and "clientKey" for mutual TLS, "tlsMinVersion" and a request "timeout". The settings are
+saved with the session and apply to every request made to the tenant.
+
+The tenant is reached over HTTPS on the default port. Set "server" to the base URL to use instead, such
+as a vanity domain with a path prefix, a custom port or a local server over plain HTTP. The tenant
+remains the name of the session and contexts.
+
+A tenant can have several named credentials, such as a read-only API client for day-to-day work and
+an admin client. Login with the global "--credential" flag to save the credential under a name. Commands
+select the least privileged credential of the tenant that is granted the entitlements they need, unless
+"--credential" is used to select one.
+
+In environments where the configuration file cannot be used, such as CI pipelines, credentials can
+be provided to any command using the environment variables VERIFY_TENANT, VERIFY_TOKEN, VERIFY_CLIENT_ID,
+VERIFY_CLIENT_SECRET, VERIFY_PRIVATE_KEY and VERIFY_SERVER, or the global flags "--tenant", "--token",
+"--client-id", "--private-key" and "--server". Flags take precedence over environment variables, which take precedence over the
+configuration file. Tokens obtained this way are never saved.
+
 The auth resource file can be generated using:
 
   verifyctl auth --boilerplate`))
@@ -44,6 +85,42 @@ The auth resource file can be generated using:
 		# The connection created is permitted to perform actions based on the entitlements that
 		# are configured on the OAuth client and the entitlements of the user based on assigned groups and roles.
 		verifyctl auth -f=login.yaml
+
+		# Login as a user with the device flow and open the verification page in the browser
+		verifyctl auth -f=login.yaml --open-browser
+
+		# Login as a user in the browser. The auth resource file sets 'flow: authorization_code'
+		# and the application on Verify should allow the redirect URI 'http://127.0.0.1/callback'
+		# or the 'redirect_uri' set in the file.
+		verifyctl auth -f=browser-login.yaml
+
+		# Login as an API client and save its client secret, so that the token is renewed without
+		# logging in again
+		verifyctl auth -f=login.yaml --save-secret
+
+		# Generate a key pair for an API client that uses private_key_jwt
+		verifyctl auth keygen --out=client_key.pem
+
+		# Login as an API client whose secret is read from a secret manager. The auth resource file has:
+		#
+		#   exec:
+		#     command: vault-verify-credential
+		#     args: ["--client", "ci"]
+		verifyctl auth -f=exec-login.yaml
+
+		# Save a read-only API client and an admin API client for the same tenant. Commands use
+		# the read-only client whenever it is sufficient.
+		verifyctl auth -f=reader.yaml --credential=reader
+		verifyctl auth -f=admin.yaml --credential=admin
+
+		# Display who the current session belongs to
+		verifyctl auth status
+
+		# Check if the current session is allowed to delete groups
+		verifyctl auth can-i delete groups
+
+		# Print the token of the current session as JSON for other tools
+		verifyctl auth token --min-ttl=5m
 	`))
 )
 
@@ -54,6 +131,8 @@ type options struct {
 	clientSecret string
 	tenant       string
 	printOnly    bool
+	openBrowser  bool
+	saveSecret   bool
 	file         string
 
 	config *config.CLIConfig
@@ -85,6 +164,12 @@ func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string)
 
 	o.AddFlags(cmd)
 
+	// add sub commands
+	cmd.AddCommand(newStatusCommand(config, streams))
+	cmd.AddCommand(newKeygenCommand(config, streams))
+	cmd.AddCommand(newCanICommand(config, streams))
+	cmd.AddCommand(newTokenCommand(config, streams))
+
 	return cmd
 }
 
@@ -92,6 +177,8 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&o.boilerplate, "boilerplate", o.boilerplate, i18n.TranslateWithArgs("Generate an empty %s file. This will be in YAML format.", "auth"))
 	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to the file parameters used to authenticate the request. JSON and YAML formats are supported and the files are expected to be named with the appropriate extension: json, yml or yaml."))
 	cmd.Flags().BoolVar(&o.printOnly, "print", false, i18n.Translate("Specify if the OAuth 2.0 access token should only be displayed and not persisted. Note that this means subsequent commands will not be able to make use of this token."))
+	cmd.Flags().BoolVar(&o.openBrowser, "open-browser", false, i18n.Translate("Open the verification page in the default browser when logging in with the device flow."))
+	cmd.Flags().BoolVar(&o.saveSecret, "save-secret", false, i18n.Translate("Save the client secret or private key of the API client in the configuration file, so that the token can be renewed without logging in again. They are saved in the clear unless the credential store is encrypted."))
 	cmd.Flags().BoolVarP(&o.user, "user", "u", o.user, i18n.Translate("(Deprecated) Specify if a user login should be initiated."))
 	cmd.Flags().StringVar(&o.clientID, "clientId", o.clientID, i18n.Translate("(Deprecated) Client ID of the API client or application enabled the appropriate grant type."))
 	cmd.Flags().StringVar(&o.clientSecret, "clientSecret", o.clientSecret, i18n.Translate("(Deprecated) Client Secret of the API client or application enabled the appropriate grant type. This is optional if the application is configured as a public client."))
@@ -134,7 +221,7 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 					"foo": []string{"bar"},
 				},
 				ClientAuthType: "private_key_jwt",
-				PrivateKeyRaw:  "<serialized_jwk> when auth_type is private_key_jwt",
+				PrivateKeyRaw:  "<serialized_jwk or PEM, or @path to the file> when auth_type is private_key_jwt",
 			},
 		}
 
@@ -142,7 +229,6 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	token := ""
 	var authResource *AuthResource
 	var err error
 
@@ -171,34 +257,55 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return errorsx.G11NError("'tenant' is required.")
 	}
 
-	if tokenResponse, err := o.authenticate(cmd, authResource); err != nil {
-		vc.Logger.Warn("authentication failed", "client", authResource.ClientID, "err", err)
+	authConfig := &config.AuthConfig{
+		Tenant:     authResource.Tenant,
+		Name:       o.config.CredentialOverride(),
+		Server:     authResource.Server,
+		User:       authResource.User,
+		Scopes:     authResource.Scopes,
+		Client:     authResource.ClientConfig(),
+		SaveSecret: o.saveSecret,
+		Exec:       authResource.Exec,
+		Network:    authResource.Network.WithAbsolutePaths(),
+	}
+
+	if err := authConfig.ApplyNetwork(); err != nil {
 		return err
+	}
+
+	if authResource.Exec != nil {
+		// the credential plugin provides the token or the client credentials
+		err = authConfig.Refresh(ctx)
 	} else {
-		token = tokenResponse.AccessToken
+		var tokenResponse *oidc.TokenResponse
+		if tokenResponse, err = o.authenticate(cmd, authResource); err == nil {
+			authConfig.SetToken(tokenResponse)
+		}
+	}
+
+	if err != nil {
+		vc.Logger.Warn("authentication failed", "client", authResource.ClientID, "err", err)
+		return err
 	}
 
 	if o.printOnly {
-		cmdutil.WriteString(cmd, token)
+		cmdutil.WriteString(cmd, authConfig.Token)
 		return nil
 	}
 
-	// add token to config
-	if _, err := o.config.LoadFromFile(); err != nil {
-		return err
+	// tag the credential with its entitlements, so that commands can select it
+	if _, err := entitlements.Granted(ctx, o.config, authConfig); err != nil {
+		vc.Logger.Warnf("unable to determine the granted entitlements; tenant=%s, err=%v", authConfig.Tenant, err)
 	}
 
-	o.config.AddAuth(&config.AuthConfig{
-		Tenant: authResource.Tenant,
-		Token:  token,
-		User:   authResource.User,
+	// add token to config and set current tenant
+	err = o.config.Update(func(c *config.CLIConfig) error {
+		c.AddAuth(authConfig)
+		c.SetCurrentTenant(authResource.Tenant)
+		return nil
 	})
 
-	// set current tenant
-	o.config.SetCurrentTenant(authResource.Tenant)
-
-	// persist contents
-	if _, err := o.config.PersistFile(); err != nil {
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+	"github.com/spf13/cobra"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	// defaultRedirectURI is used when the auth resource does not set 'redirect_uri'.
+	// The port is picked when the listener starts.
+	defaultRedirectURI = "http://127.0.0.1:0/callback"
+
+	browserLoginTimeout = 5 * time.Minute
+)
+
+const callbackPage = `<!DOCTYPE html>
+<html>
+<head><title>verifyctl</title></head>
+<body><p>%s</p></body>
+</html>`
+
+// authenticateWithBrowser runs the authorization code flow with PKCE. A loopback listener
+// receives the redirect from the tenant and the code is exchanged for a token.
+func (o *options) authenticateWithBrowser(cmd *cobra.Command, client *oidc.Client, r *AuthResource) (*oidc.TokenResponse, error) {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+
+	redirectURI := r.RedirectURI
+	if len(redirectURI) == 0 {
+		redirectURI = defaultRedirectURI
+	}
+
+	redirectURL, err := parseLoopbackURL(redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", redirectURL.Host)
+	if err != nil {
+		vc.Logger.Errorf("unable to start the loopback listener; err=%v", err)
+		return nil, errorsx.G11NError("unable to listen on '%s'; err=%v", redirectURL.Host, err)
+	}
+
+	// the port is known only once the listener is bound
+	port := listener.Addr().(*net.TCPAddr).Port
+	redirectURL.Host = net.JoinHostPort(redirectURL.Hostname(), strconv.Itoa(port))
+	client.RedirectURL = redirectURL.String()
+
+	authResponse, err := client.AuthorizeWithBrowserFlow(ctx, r.Parameters)
+	if err != nil {
+		_ = listener.Close()
+		vc.Logger.Errorf("Failed to initiate the authorization code flow: err=%v", err)
+		return nil, err
+	}
+
+	callbacks := make(chan url.Values, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirectURL.Path, callbackHandler(authResponse.State, callbacks))
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			vc.Logger.Errorf("loopback listener failed; err=%v", err)
+		}
+	}()
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	// the browser does not use the transport, so the URL is resolved to the server here
+	authCodeURL := authResponse.AuthCodeURL
+	if u, err := url.Parse(authCodeURL); err == nil {
+		authCodeURL = xhttp.ResolveURL(u).String()
+	}
+
+	if err := cmdutil.OpenBrowser(authCodeURL); err != nil {
+		vc.Logger.Warnf("unable to open the browser; err=%v", err)
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Login with %s", authCodeURL))
+
+	var callbackParams url.Values
+	select {
+	case callbackParams = <-callbacks:
+	case <-time.After(browserLoginTimeout):
+		return nil, errorsx.G11NError("timed out waiting for the login to complete")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tokenResponse, err := config.TokenWithAuthCode(ctx, client, authResponse, callbackParams)
+	if err != nil {
+		vc.Logger.Errorf("Unable to get a token: err=%v", err)
+		return nil, err
+	}
+
+	return tokenResponse, nil
+}
+
+// parseLoopbackURL checks that the redirect URI is a plain HTTP URL on the local machine.
+func parseLoopbackURL(redirectURI string) (*url.URL, error) {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return nil, errorsx.G11NError("invalid 'redirect_uri'; err=%v", err)
+	}
+
+	if u.Scheme != "http" {
+		return nil, errorsx.G11NError("'redirect_uri' must use the 'http' scheme.")
+	}
+
+	host := u.Hostname()
+	if host != "localhost" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return nil, errorsx.G11NError("'redirect_uri' must be a loopback address, such as 127.0.0.1 or localhost.")
+		}
+	}
+
+	if len(u.Port()) == 0 {
+		u.Host = net.JoinHostPort(host, "0")
+	}
+
+	if len(u.Path) == 0 {
+		u.Path = "/"
+	}
+
+	return u, nil
+}
+
+// callbackHandler sends the parameters of the redirect for the login with the state to
+// the channel, and shows a page to the user.
+func callbackHandler(state string, callbacks chan<- url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		params := req.URL.Query()
+		// ignore requests that were not issued for this login, including errors, so that
+		// other local pages cannot cancel it
+		if params.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		message := i18n.Translate("Login completed. You can close this window and return to verifyctl.")
+		if e := params.Get("error"); len(e) > 0 {
+			message = i18n.TranslateWithArgs("Login failed: %s. You can close this window.", e)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = fmt.Fprintf(w, callbackPage, html.EscapeString(message))
+
+		select {
+		case callbacks <- params:
+		default:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		want   int
+		wantCb bool
+	}{
+		{
+			name:   "accepts the code for the login",
+			query:  "code=abc&state=expected",
+			want:   http.StatusOK,
+			wantCb: true,
+		},
+		{
+			name:   "accepts an error for the login",
+			query:  "error=access_denied&state=expected",
+			want:   http.StatusOK,
+			wantCb: true,
+		},
+		{
+			name:  "ignores a code for another login",
+			query: "code=abc&state=other",
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "ignores an error without the state",
+			query: "error=access_denied",
+			want:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callbacks := make(chan url.Values, 1)
+			w := httptest.NewRecorder()
+			callbackHandler("expected", callbacks)(w, httptest.NewRequest(http.MethodGet, "/callback?"+tt.query, nil))
+
+			if w.Code != tt.want {
+				t.Errorf("callbackHandler() status = %d, want %d", w.Code, tt.want)
+			}
+
+			if got := len(callbacks) > 0; got != tt.wantCb {
+				t.Errorf("callbackHandler() sent the callback = %v, want %v", got, tt.wantCb)
+			}
+		})
+	}
+}
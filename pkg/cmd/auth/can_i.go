@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	canIUsage         = "can-i VERB RESOURCE [flags]"
+	canIMessagePrefix = "AuthCanI"
+)
+
+var (
+	canILongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(canIMessagePrefix, `
+		Check whether the current session is allowed to perform an action.
+
+The entitlements granted to the token of the current context are compared against the entitlements
+required by the command. The command prints 'yes' or 'no' and exits with a non-zero status if the
+action is not allowed. If the tenant has several credentials, the one that the command would select
+is checked, and the list includes the actions allowed by any of them.
+
+The verbs are the commands that manage resources: get, create, replace, delete and set. The resources
+are the ones supported by those commands, such as users, groups and themes.`))
+
+	canIExamples = templates.Examples(cmdutil.TranslateExamples(canIMessagePrefix, `
+		# Check if users can be created
+		verifyctl auth can-i create users
+
+		# List all the actions and whether they are allowed
+		verifyctl auth can-i --list`))
+)
+
+type canIOptions struct {
+	list         bool
+	verb         string
+	resourceName string
+	quiet        bool
+
+	config *config.CLIConfig
+}
+
+func newCanICommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &canIOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   canIUsage,
+		Short:                 cmdutil.TranslateShortDesc(canIMessagePrefix, "Check whether the current session is allowed to perform an action."),
+		Long:                  canILongDesc,
+		Example:               canIExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *canIOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.list, "list", false, i18n.Translate("List all the actions and whether the current session is allowed to perform them."))
+	cmd.Flags().BoolVarP(&o.quiet, "quiet", "q", false, i18n.Translate("Do not print the result. The exit status indicates whether the action is allowed."))
+}
+
+func (o *canIOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.verb = args[0]
+	}
+
+	if len(args) > 1 {
+		o.resourceName = args[1]
+	}
+
+	return nil
+}
+
+func (o *canIOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.list {
+		return nil
+	}
+
+	if len(args) != 2 {
+		return errorsx.G11NError("'VERB' and 'RESOURCE' are required.")
+	}
+
+	_, err := entitlements.Lookup(o.verb, o.resourceName)
+	return err
+}
+
+func (o *canIOptions) Run(cmd *cobra.Command, args []string) error {
+	if o.list {
+		granted, err := o.grantedToAll(cmd)
+		if err != nil {
+			return err
+		}
+
+		return o.printList(cmd, granted)
+	}
+
+	ctx := cmd.Context()
+	rule, _ := entitlements.Lookup(o.verb, o.resourceName)
+	authConfig, err := entitlements.SelectCredential(ctx, o.config, rule)
+	if err != nil {
+		return err
+	}
+
+	granted, err := entitlements.Granted(ctx, o.config, authConfig)
+	if err != nil {
+		return err
+	}
+
+	if granted == nil {
+		return errorsx.G11NError("The token does not include the granted entitlements.")
+	}
+
+	if rule.Allows(granted) {
+		if o.quiet {
+			return nil
+		}
+
+		if len(authConfig.Name) > 0 {
+			cmdutil.WriteString(cmd, i18n.TranslateWithArgs("yes - using the credential '%s'", authConfig.Name))
+		} else {
+			cmdutil.WriteString(cmd, "yes")
+		}
+
+		return nil
+	}
+
+	if !o.quiet {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("no - requires %s", rule.Describe()))
+	}
+
+	return &cmdutil.ExitError{Code: 1}
+}
+
+// grantedToAll returns the entitlements granted to any of the credentials of the tenant.
+func (o *canIOptions) grantedToAll(cmd *cobra.Command) ([]string, error) {
+	ctx := cmd.Context()
+	credentials, err := o.config.CurrentCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var granted []string
+	for _, authConfig := range credentials {
+		if err := o.config.ActivateAuth(ctx, authConfig); err != nil {
+			return nil, err
+		}
+
+		g, err := entitlements.Granted(ctx, o.config, authConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		granted = append(granted, g...)
+	}
+
+	if granted == nil {
+		return nil, errorsx.G11NError("The token does not include the granted entitlements.")
+	}
+
+	return granted, nil
+}
+
+func (o *canIOptions) printList(cmd *cobra.Command, granted []string) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 10, 1, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERB\tRESOURCE\tALLOWED\tENTITLEMENTS")
+	for _, rule := range entitlements.Rules() {
+		allowed := "no"
+		if rule.Allows(granted) {
+			allowed = "yes"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", rule.Verb, rule.Resource, allowed, strings.Join(rule.AnyOf, ","))
+	}
+
+	return w.Flush()
+}
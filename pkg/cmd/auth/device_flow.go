@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+	"github.com/ibm-verify/verifyctl/pkg/util/qrcode"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultPollInterval is used when the tenant does not return an interval.
+	defaultPollInterval = 5 * time.Second
+
+	// slowDownIncrement is added to the interval for every 'slow_down' response.
+	slowDownIncrement = 5 * time.Second
+)
+
+type deviceTokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// authenticateWithDevice runs the OAuth 2.0 Device Authorization Grant. The verification
+// URI is shown as a QR code along with the user code, and the token endpoint is polled
+// until the user completes the login, the device code expires or the user presses Ctrl-C.
+func (o *options) authenticateWithDevice(cmd *cobra.Command, client *oidc.Client, r *AuthResource) (*oidc.TokenResponse, error) {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	vc := contextx.GetVerifyContext(ctx)
+	deviceAuthResponse, err := client.AuthorizeWithDeviceFlow(ctx, r.Parameters)
+	if err != nil {
+		vc.Logger.Errorf("Failed to initiate device flow: err=%v", err)
+		return nil, err
+	}
+
+	o.printDeviceLogin(cmd, deviceAuthResponse)
+
+	status := newDeviceStatus(cmd.OutOrStdout(), deviceAuthResponse.Expiry)
+	defer status.done()
+
+	interval := time.Duration(deviceAuthResponse.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	tokenURL, _ := url.Parse((&config.AuthConfig{Tenant: r.Tenant}).TenantURL() + "/oauth2/token")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	next := time.Now().Add(interval)
+	for {
+		select {
+		case <-ctx.Done():
+			// the device code is abandoned and expires on the tenant
+			return nil, errorsx.G11NError("The login was cancelled.")
+		case now := <-ticker.C:
+			if status.expired(now) {
+				return nil, errorsx.G11NError("The device code has expired. Run the command again to log in.")
+			}
+
+			if now.Before(next) {
+				status.update(now)
+				continue
+			}
+		}
+
+		tokenResponse, errorCode, err := pollDeviceToken(ctx, client, tokenURL, deviceAuthResponse.DeviceCode)
+		switch {
+		case ctx.Err() != nil:
+			// handled by the cancellation above
+			continue
+		case err != nil:
+			vc.Logger.Errorf("Unable to get a token: err=%v", err)
+			return nil, err
+		case tokenResponse != nil:
+			return tokenResponse, nil
+		case errorCode == "slow_down":
+			interval += slowDownIncrement
+			status.setMessage(i18n.TranslateWithArgs("The tenant asked to poll less often. Checking every %s.", interval))
+		case errorCode == "authorization_pending":
+			status.setMessage(i18n.Translate("Waiting for the login to be completed."))
+		}
+
+		next = time.Now().Add(interval)
+		status.update(time.Now())
+	}
+}
+
+// printDeviceLogin shows the verification URI, the user code and the QR code, and opens
+// the browser if requested.
+func (o *options) printDeviceLogin(cmd *cobra.Command, deviceAuthResponse *oidc.DeviceAuthResponse) {
+	vc := contextx.GetVerifyContext(cmd.Context())
+	verificationURI := deviceAuthResponse.VerificationURIComplete
+	if len(verificationURI) == 0 {
+		verificationURI = deviceAuthResponse.VerificationURI
+	}
+
+	if code, err := qrcode.Encode(verificationURI); err != nil {
+		vc.Logger.Warnf("unable to render the verification URI as a QR code; err=%v", err)
+	} else {
+		_, _ = io.WriteString(cmd.OutOrStdout(), code.Terminal())
+	}
+
+	if len(deviceAuthResponse.VerificationURIComplete) > 0 {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Scan the QR code or open %s and check that the page shows the code below.", verificationURI))
+	} else {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Scan the QR code or open %s and enter the code below.", verificationURI))
+	}
+
+	cmdutil.WriteString(cmd, "")
+	cmdutil.WriteString(cmd, "    "+deviceAuthResponse.UserCode)
+	cmdutil.WriteString(cmd, "")
+
+	if !o.openBrowser {
+		return
+	}
+
+	if err := cmdutil.OpenBrowser(verificationURI); err != nil {
+		vc.Logger.Warnf("unable to open the browser; err=%v", err)
+		cmdutil.WriteString(cmd, i18n.Translate("The browser could not be opened. Open the link manually."))
+	}
+}
+
+// pollDeviceToken makes a single token request. The error code is returned when the
+// tenant responds with 'authorization_pending' or 'slow_down', which are not failures.
+func pollDeviceToken(ctx context.Context, client *oidc.Client, tokenURL *url.URL, deviceCode string) (*oidc.TokenResponse, string, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return nil, "", err
+	}
+
+	params.Set("grant_type", deviceCodeGrantType)
+	params.Set("device_code", deviceCode)
+
+	headers := http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{"application/x-www-form-urlencoded"},
+	}
+
+	response, err := xhttp.NewDefaultClient().Post(ctx, tokenURL, headers, []byte(params.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if response.StatusCode == http.StatusOK {
+		tokenResponse := &oidc.TokenResponse{}
+		if err := json.Unmarshal(response.Body, tokenResponse); err != nil {
+			return nil, "", errorsx.G11NError("unable to parse the token response")
+		}
+
+		return tokenResponse, "", nil
+	}
+
+	errorResponse := &deviceTokenError{}
+	_ = json.Unmarshal(response.Body, errorResponse)
+	switch errorResponse.Error {
+	case "authorization_pending", "slow_down":
+		return nil, errorResponse.Error, nil
+	case "access_denied":
+		return nil, "", errorsx.G11NError("The login was denied.")
+	case "expired_token":
+		return nil, "", errorsx.G11NError("The device code has expired. Run the command again to log in.")
+	}
+
+	vc.Logger.Errorf("unable to get the token; code=%d, error=%s, description=%s", response.StatusCode, errorResponse.Error, errorResponse.ErrorDescription)
+	if len(errorResponse.ErrorDescription) > 0 {
+		return nil, "", errorsx.G11NError("The login failed: %s", errorResponse.ErrorDescription)
+	}
+
+	return nil, "", errorsx.G11NError("The login failed with the status code %d.", response.StatusCode)
+}
+
+// deviceStatus shows the time left to complete the login. On a terminal the line is
+// redrawn every second, otherwise only changes to the message are written.
+type deviceStatus struct {
+	out         io.Writer
+	expiry      time.Time
+	interactive bool
+	message     string
+	written     bool
+}
+
+func newDeviceStatus(out io.Writer, expiry time.Time) *deviceStatus {
+	s := &deviceStatus{
+		out:     out,
+		expiry:  expiry,
+		message: i18n.Translate("Waiting for the login to be completed."),
+	}
+
+	if f, ok := out.(*os.File); ok {
+		s.interactive = term.IsTerminal(int(f.Fd()))
+	}
+
+	s.update(time.Now())
+	return s
+}
+
+func (s *deviceStatus) expired(now time.Time) bool {
+	return !s.expiry.IsZero() && !now.Before(s.expiry)
+}
+
+func (s *deviceStatus) setMessage(message string) {
+	if message == s.message {
+		return
+	}
+
+	s.message = message
+	if !s.interactive {
+		_, _ = fmt.Fprintln(s.out, message)
+	}
+}
+
+func (s *deviceStatus) update(now time.Time) {
+	if !s.interactive {
+		if !s.written {
+			_, _ = fmt.Fprintln(s.out, s.line(now))
+			s.written = true
+		}
+
+		return
+	}
+
+	// clear the rest of the previous line
+	_, _ = fmt.Fprintf(s.out, "\r%s\033[K", s.line(now))
+	s.written = true
+}
+
+// done ends the status line so that the next output starts on a new line.
+func (s *deviceStatus) done() {
+	if s.interactive && s.written {
+		_, _ = fmt.Fprintln(s.out)
+	}
+
+	s.written = false
+}
+
+func (s *deviceStatus) line(now time.Time) string {
+	if s.expiry.IsZero() {
+		return s.message
+	}
+
+	remaining := s.expiry.Sub(now).Round(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return strings.TrimSpace(s.message + " " + i18n.TranslateWithArgs("The code expires in %s.", remaining))
+}
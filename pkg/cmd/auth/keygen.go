@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	keygenUsage         = "keygen [flags]"
+	keygenMessagePrefix = "AuthKeygen"
+
+	rsaKeyType     = "rsa"
+	ecKeyType      = "ec"
+	ed25519KeyType = "ed25519"
+
+	pemKeyFormat = "pem"
+	jwkKeyFormat = "jwk"
+)
+
+var (
+	keygenLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(keygenMessagePrefix, `
+		Generate a key pair for 'private_key_jwt' client authentication.
+
+The private key is written to the file set by "--out" and must be kept secret. In the PEM format,
+the public key is also written next to it with the ".pub" suffix.
+
+The public key is printed as a JWK. Register it on the API client or application on Verify and
+reference the private key in the auth resource file:
+
+  auth_type: private_key_jwt
+  key: "@private_key.pem"
+
+PEM files do not carry the algorithm or the key ID. If "--alg" or "--kid" is set, also set "alg"
+and "kid" in the auth resource file.`))
+
+	keygenExamples = templates.Examples(cmdutil.TranslateExamples(keygenMessagePrefix, `
+		# Generate an RSA key pair and print the public JWK
+		verifyctl auth keygen
+
+		# Generate an EC P-384 key as a JWK
+		verifyctl auth keygen --type=ec --curve=P-384 --format=jwk --out=key.json
+
+		# Generate an RSA key signed with PS256
+		verifyctl auth keygen --alg=PS256 --out=ci_key.pem`))
+)
+
+type keygenOptions struct {
+	keyType string
+	bits    int
+	curve   string
+	alg     string
+	kid     string
+	out     string
+	format  string
+	force   bool
+
+	config *config.CLIConfig
+}
+
+func newKeygenCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &keygenOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   keygenUsage,
+		Short:                 cmdutil.TranslateShortDesc(keygenMessagePrefix, "Generate a key pair for 'private_key_jwt' client authentication."),
+		Long:                  keygenLongDesc,
+		Example:               keygenExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *keygenOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.keyType, "type", rsaKeyType, i18n.Translate("Type of the key. The values supported are 'rsa', 'ec' and 'ed25519'."))
+	cmd.Flags().IntVar(&o.bits, "bits", 2048, i18n.Translate("Size of the RSA key in bits."))
+	cmd.Flags().StringVar(&o.curve, "curve", "P-256", i18n.Translate("Curve of the EC key. The values supported are 'P-256', 'P-384' and 'P-521'."))
+	cmd.Flags().StringVar(&o.alg, "alg", "", i18n.Translate("Signing algorithm of the key. By default, RS256 is used for RSA keys and the algorithm matching the curve for EC keys."))
+	cmd.Flags().StringVar(&o.kid, "kid", "", i18n.Translate("Key ID. By default, the RFC 7638 thumbprint of the key is used."))
+	cmd.Flags().StringVar(&o.out, "out", "private_key.pem", i18n.Translate("Path of the file the private key is written to."))
+	cmd.Flags().StringVar(&o.format, "format", pemKeyFormat, i18n.Translate("Format of the private key file. The values supported are 'pem' and 'jwk'."))
+	cmd.Flags().BoolVar(&o.force, "force", false, i18n.Translate("Overwrite the key files if they exist."))
+}
+
+func (o *keygenOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.keyType = strings.ToLower(o.keyType)
+	o.format = strings.ToLower(o.format)
+	return nil
+}
+
+func (o *keygenOptions) Validate(cmd *cobra.Command, args []string) error {
+	switch o.keyType {
+	case rsaKeyType:
+		if o.bits < 2048 {
+			return errorsx.G11NError("'bits' must be at least 2048.")
+		}
+	case ecKeyType, ed25519KeyType:
+	default:
+		return errorsx.G11NError("unsupported key type '%s'.", o.keyType)
+	}
+
+	if o.format != pemKeyFormat && o.format != jwkKeyFormat {
+		return errorsx.G11NError("unsupported format '%s'.", o.format)
+	}
+
+	if len(o.out) == 0 {
+		return errorsx.G11NError("'out' is required.")
+	}
+
+	return nil
+}
+
+func (o *keygenOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+
+	key, err := o.generateKey()
+	if err != nil {
+		vc.Logger.Errorf("unable to generate the key; err=%v", err)
+		return err
+	}
+
+	jwk := &jose.JSONWebKey{
+		Key: key,
+		Use: "sig",
+	}
+
+	if err := o.completeJWK(jwk); err != nil {
+		return err
+	}
+
+	files := map[string][]byte{}
+	publicPath := ""
+	if o.format == jwkKeyFormat {
+		b, err := json.MarshalIndent(jwk, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		files[o.out] = b
+	} else {
+		privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return err
+		}
+
+		publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			return err
+		}
+
+		ext := filepath.Ext(o.out)
+		publicPath = strings.TrimSuffix(o.out, ext) + ".pub" + ext
+		files[o.out] = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})
+		files[publicPath] = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	}
+
+	if !o.force {
+		for path := range files {
+			if _, err := os.Stat(path); err == nil {
+				return errorsx.G11NError("'%s' already exists. Use '--force' to overwrite it.", path)
+			}
+		}
+	}
+
+	if err := cmdutil.WritePrivateFile(o.out, files[o.out]); err != nil {
+		return err
+	}
+
+	if len(publicPath) > 0 {
+		if err := os.WriteFile(publicPath, files[publicPath], 0644); err != nil {
+			return err
+		}
+	}
+
+	publicJWK := config.PublicJWK(jwk)
+	cmdutil.WriteAsJSON(cmd, &publicJWK, cmd.OutOrStdout())
+	cmdutil.WriteString(cmd, "")
+	return nil
+}
+
+func (o *keygenOptions) generateKey() (crypto.Signer, error) {
+	switch o.keyType {
+	case ecKeyType:
+		var curve elliptic.Curve
+		switch strings.ToUpper(o.curve) {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errorsx.G11NError("unsupported curve '%s'.", o.curve)
+		}
+
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case ed25519KeyType:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return rsa.GenerateKey(rand.Reader, o.bits)
+	}
+}
+
+// completeJWK sets the algorithm and key ID the same way they are derived when
+// the key is loaded for 'private_key_jwt', so that the printed JWK matches.
+func (o *keygenOptions) completeJWK(jwk *jose.JSONWebKey) error {
+	b, err := json.Marshal(jwk)
+	if err != nil {
+		return err
+	}
+
+	loaded, err := config.LoadPrivateKey(string(b), &config.PrivateKeyOptions{
+		Algorithm: o.alg,
+		KeyID:     o.kid,
+	})
+	if err != nil {
+		return err
+	}
+
+	jwk.Algorithm = loaded.Algorithm
+	jwk.KeyID = loaded.KeyID
+	return nil
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"io"
+	"slices"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/auth"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+)
+
+const (
+	logoutUsage         = "logout [flags]"
+	logoutMessagePrefix = "Logout"
+)
+
+var (
+	logoutLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(logoutMessagePrefix, `
+		Log out of your tenant.
+
+The access token and refresh token of the session are revoked on the tenant and the session is
+removed from the configuration file, along with the contexts that refer to the tenant. All the
+credentials of the tenant are removed, unless one is selected using the global '--credential' flag.
+
+Sessions saved by older versions of the client do not include the client details needed to revoke
+the tokens. These sessions are only removed from the configuration file.`))
+
+	logoutExamples = templates.Examples(cmdutil.TranslateExamples(logoutMessagePrefix, `
+		# Log out of the current tenant
+		verifyctl logout
+
+		# Log out of the tenant of the "staging" context
+		verifyctl logout --context=staging
+
+		# Remove only the credential named "admin" of the current tenant
+		verifyctl logout --credential=admin
+
+		# Log out of every tenant
+		verifyctl logout --all`))
+)
+
+type logoutOptions struct {
+	all bool
+
+	config *config.CLIConfig
+}
+
+func NewLogoutCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &logoutOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   logoutUsage,
+		Short:                 cmdutil.TranslateShortDesc(logoutMessagePrefix, "Log out of your tenant."),
+		Long:                  logoutLongDesc,
+		Example:               logoutExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *logoutOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.all, "all", false, i18n.Translate("Log out of every tenant in the configuration file."))
+}
+
+func (o *logoutOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *logoutOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *logoutOptions) Run(cmd *cobra.Command, args []string) error {
+	sessions := []*config.AuthConfig{}
+	if o.all {
+		sessions = append(sessions, o.config.Auth...)
+	} else {
+		credentials, err := o.config.CurrentCredentials()
+		if err != nil {
+			return err
+		}
+
+		sessions = append(sessions, credentials...)
+	}
+
+	// the saved tokens are needed to revoke them
+	if slices.ContainsFunc(sessions, func(c *config.AuthConfig) bool { return !c.IsEphemeral() }) {
+		if err := o.config.UnlockCredentials(); err != nil {
+			return err
+		}
+	}
+
+	removed := []*config.AuthConfig{}
+	for _, authConfig := range sessions {
+		o.revoke(cmd, authConfig)
+		if authConfig.IsEphemeral() {
+			// credentials from flags or the environment are not in the file
+			continue
+		}
+
+		removed = append(removed, authConfig)
+	}
+
+	err := o.config.Update(func(c *config.CLIConfig) error {
+		for _, authConfig := range removed {
+			c.RemoveCredential(authConfig.Tenant, authConfig.Name)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, authConfig := range removed {
+		if len(authConfig.Name) > 0 {
+			cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Logged out of %s with the credential '%s'.", authConfig.Tenant, authConfig.Name))
+		} else {
+			cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Logged out of %s.", authConfig.Tenant))
+		}
+	}
+
+	return nil
+}
+
+// revoke invalidates the tokens of the session. Failures are reported but do not stop
+// the session from being removed locally.
+func (o *logoutOptions) revoke(cmd *cobra.Command, authConfig *config.AuthConfig) {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+
+	if err := authConfig.ApplyNetwork(); err != nil {
+		vc.Logger.Errorf("unable to apply the network settings; tenant=%s, err=%v", authConfig.Tenant, err)
+	}
+
+	if authConfig.Client == nil {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("The tokens for %s cannot be revoked as the session does not include the client details.", authConfig.Tenant))
+		return
+	}
+
+	authResource, err := newAuthResourceFromConfig(authConfig)
+	if err != nil {
+		vc.Logger.Errorf("unable to build the client; tenant=%s, err=%v", authConfig.Tenant, err)
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("The tokens for %s could not be revoked: %s", authConfig.Tenant, err.Error()))
+		return
+	}
+
+	client := authResource.ConvertToClient()
+	c := auth.NewTokenClient()
+	// the refresh token goes first so a new access token cannot be minted in between
+	tokens := []struct {
+		hint  string
+		value string
+	}{
+		{"refresh_token", authConfig.RefreshToken},
+		{"access_token", authConfig.Token},
+	}
+
+	for _, token := range tokens {
+		if len(token.value) == 0 {
+			continue
+		}
+
+		if err := c.Revoke(ctx, authConfig, client, token.value, token.hint); err != nil {
+			cmdutil.WriteString(cmd, i18n.TranslateWithArgs("The %s for %s could not be revoked: %s", token.hint, authConfig.Tenant, err.Error()))
+		}
+	}
+}
@@ -3,13 +3,12 @@ package auth
 import (
 	"encoding/json"
 	"net/url"
-	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-jose/go-jose/v4"
-	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
-	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/config"
 	"github.com/spf13/cobra"
 
 	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
@@ -17,9 +16,21 @@ import (
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
 )
 
+const (
+	// DeviceCodeFlow is the OAuth 2.0 Device Authorization Grant. It is used for
+	// user logins when no flow is set.
+	DeviceCodeFlow = "device_code"
+
+	// AuthorizationCodeFlow is the OAuth 2.0 authorization code grant with PKCE.
+	// The browser is redirected to a loopback listener started by verifyctl.
+	AuthorizationCodeFlow = "authorization_code"
+)
+
 type AuthResource struct {
 	Tenant string `yaml:"tenant" json:"tenant"`
 
+	Server string `yaml:"server,omitempty" json:"server,omitempty"`
+
 	ClientID string `yaml:"client_id" json:"client_id"`
 
 	ClientAuthType string `yaml:"auth_type" json:"auth_type"`
@@ -32,9 +43,23 @@ type AuthResource struct {
 
 	User bool `yaml:"user" json:"user"`
 
+	Flow string `yaml:"flow,omitempty" json:"flow,omitempty"`
+
+	RedirectURI string `yaml:"redirect_uri,omitempty" json:"redirect_uri,omitempty"`
+
 	PrivateKeyRaw string `yaml:"key" json:"key"`
 
+	CertificateRaw string `yaml:"cert,omitempty" json:"cert,omitempty"`
+
+	Algorithm string `yaml:"alg,omitempty" json:"alg,omitempty"`
+
+	KeyID string `yaml:"kid,omitempty" json:"kid,omitempty"`
+
 	PrivateKeyJWK *jose.JSONWebKey `yaml:"-" json:"-"`
+
+	Exec *config.ExecConfig `yaml:"exec,omitempty" json:"exec,omitempty"`
+
+	Network *config.NetworkConfig `yaml:"network,omitempty" json:"network,omitempty"`
 }
 
 func (r *AuthResource) ConvertToClient() *oidc.Client {
@@ -43,12 +68,8 @@ func (r *AuthResource) ConvertToClient() *oidc.Client {
 		Scopes: r.Scopes,
 	}
 
-	if r.ClientAuthType == "private_key_jwt" {
-		client.ClientAuth = &oidc.PrivateKeyJWT{
-			Tenant:        r.Tenant,
-			ClientID:      r.ClientID,
-			PrivateKeyJWK: r.PrivateKeyJWK,
-		}
+	if r.ClientAuthType == config.PrivateKeyJWTAuthType {
+		client.ClientAuth = config.NewPrivateKeyJWT(r.Tenant, r.ClientID, r.PrivateKeyJWK)
 	} else {
 		client.ClientAuth = &oidc.ClientSecretPost{
 			ClientID:     r.ClientID,
@@ -64,25 +85,15 @@ func (o *options) authenticate(cmd *cobra.Command, r *AuthResource) (*oidc.Token
 	vc := contextx.GetVerifyContext(ctx)
 	client := r.ConvertToClient()
 
-	if r.User {
-		deviceAuthResponse, err := client.AuthorizeWithDeviceFlow(ctx, r.Parameters)
-		if err != nil {
-			vc.Logger.Errorf("Failed to initiate device flow: err=%v", err)
-			return nil, err
-		}
-
-		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Login with %s", deviceAuthResponse.VerificationURIComplete))
-
-		tokenResponse, err := client.TokenWithDeviceFlow(ctx, deviceAuthResponse)
-		if err != nil {
-			vc.Logger.Errorf("Unable to get a token: err=%v", err)
-			return nil, err
-		}
+	if r.Flow == AuthorizationCodeFlow {
+		return o.authenticateWithBrowser(cmd, client, r)
+	}
 
-		return tokenResponse, nil
+	if r.User {
+		return o.authenticateWithDevice(cmd, client, r)
 	}
 
-	tokenResponse, err := client.TokenWithAPIClient(cmd.Context(), r.Parameters)
+	tokenResponse, err := config.TokenWithAPIClient(cmd.Context(), client, r.Parameters)
 	if err != nil {
 		vc.Logger.Errorf("Unable to get a token: err=%v", err)
 		return nil, err
@@ -117,27 +128,92 @@ func (o *options) readFile(cmd *cobra.Command) (*AuthResource, error) {
 		return nil, err
 	}
 
+	switch authResource.Flow {
+	case "":
+	case DeviceCodeFlow, AuthorizationCodeFlow:
+		authResource.User = true
+	default:
+		return nil, errorsx.G11NError("unsupported flow '%s'. Use '%s' or '%s'.", authResource.Flow, DeviceCodeFlow, AuthorizationCodeFlow)
+	}
+
 	// if the private key is provided, extract the key
 	if authResource.PrivateKeyRaw == "" {
 		return authResource, nil
 	}
 
-	jwkAsString := authResource.PrivateKeyRaw
-	if strings.HasPrefix(authResource.PrivateKeyRaw, "@") {
-		// get the contents of the file
-		path, _ := strings.CutPrefix(authResource.PrivateKeyRaw, "@")
-		b, err := os.ReadFile(path)
-		if err != nil {
-			return nil, err
-		}
+	jwk, err := config.LoadPrivateKey(authResource.PrivateKeyRaw, authResource.ClientConfig().PrivateKeyOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	authResource.PrivateKeyJWK = jwk
+	return authResource, nil
+}
 
-		jwkAsString = string(b)
+// newAuthResourceFromConfig rebuilds the auth resource from a saved session.
+func newAuthResourceFromConfig(authConfig *config.AuthConfig) (*AuthResource, error) {
+	r := &AuthResource{
+		Tenant:         authConfig.Tenant,
+		Server:         authConfig.Server,
+		ClientID:       authConfig.Client.ClientID,
+		ClientAuthType: authConfig.Client.ClientAuthType,
+		ClientSecret:   authConfig.Client.ClientSecret,
+		Scopes:         authConfig.Scopes,
+		Parameters:     authConfig.Client.Parameters,
+		User:           authConfig.User,
+		PrivateKeyRaw:  authConfig.Client.PrivateKey,
+		CertificateRaw: authConfig.Client.Certificate,
+		Algorithm:      authConfig.Client.Algorithm,
+		KeyID:          authConfig.Client.KeyID,
+		Exec:           authConfig.Exec,
+		Network:        authConfig.Network,
 	}
 
-	authResource.PrivateKeyJWK = &jose.JSONWebKey{}
-	if err := json.Unmarshal([]byte(jwkAsString), authResource.PrivateKeyJWK); err != nil {
+	if r.PrivateKeyRaw == "" {
+		return r, nil
+	}
+
+	jwk, err := config.LoadPrivateKey(r.PrivateKeyRaw, authConfig.Client.PrivateKeyOptions())
+	if err != nil {
 		return nil, err
 	}
 
-	return authResource, nil
+	r.PrivateKeyJWK = jwk
+	return r, nil
+}
+
+// ClientConfig returns the client properties that are saved with the session so
+// that the token can be renewed later. It is nil if no client is configured, such
+// as when a credential plugin provides the client credentials.
+func (r *AuthResource) ClientConfig() *config.ClientConfig {
+	if len(r.ClientID) == 0 {
+		return nil
+	}
+
+	return &config.ClientConfig{
+		ClientID:       r.ClientID,
+		ClientAuthType: r.ClientAuthType,
+		ClientSecret:   r.ClientSecret,
+		PrivateKey:     absFileRef(r.PrivateKeyRaw),
+		Certificate:    absFileRef(r.CertificateRaw),
+		Algorithm:      r.Algorithm,
+		KeyID:          r.KeyID,
+		Parameters:     r.Parameters,
+	}
+}
+
+// absFileRef makes the path of an '@' file reference absolute, so that it can be
+// resolved from any working directory.
+func absFileRef(value string) string {
+	path, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return value
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return value
+	}
+
+	return "@" + absPath
 }
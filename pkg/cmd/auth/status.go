@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/auth"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+)
+
+const (
+	statusUsage         = "status [flags]"
+	statusMessagePrefix = "AuthStatus"
+
+	userSessionType      = "user"
+	apiClientSessionType = "apiClient"
+)
+
+var (
+	statusLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(statusMessagePrefix, `
+		Display who the current session belongs to.
+
+The token of the current context is introspected on the tenant to show the subject, the client,
+the expiry, the scopes and the entitlements that were granted. For user sessions, the userinfo
+endpoint is also called.`))
+
+	statusExamples = templates.Examples(cmdutil.TranslateExamples(statusMessagePrefix, `
+		# Show the current session
+		verifyctl auth status
+
+		# Show the session of the "prod" context as YAML
+		verifyctl auth whoami --context=prod -o=yaml`))
+)
+
+type statusOptions struct {
+	output string
+
+	config *config.CLIConfig
+}
+
+// SessionStatus describes the session that a token belongs to.
+type SessionStatus struct {
+	Context      string                 `json:"context,omitempty" yaml:"context,omitempty"`
+	Tenant       string                 `json:"tenant" yaml:"tenant"`
+	Credential   string                 `json:"credential,omitempty" yaml:"credential,omitempty"`
+	Server       string                 `json:"server" yaml:"server"`
+	Active       bool                   `json:"active" yaml:"active"`
+	Subject      string                 `json:"subject,omitempty" yaml:"subject,omitempty"`
+	ClientID     string                 `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	SessionType  string                 `json:"sessionType" yaml:"sessionType"`
+	Expiry       *time.Time             `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	Scopes       []string               `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Entitlements []string               `json:"entitlements,omitempty" yaml:"entitlements,omitempty"`
+	Userinfo     map[string]interface{} `json:"userinfo,omitempty" yaml:"userinfo,omitempty"`
+}
+
+func newStatusCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &statusOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   statusUsage,
+		Short:                 cmdutil.TranslateShortDesc(statusMessagePrefix, "Display who the current session belongs to."),
+		Long:                  statusLongDesc,
+		Example:               statusExamples,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"whoami"},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *statusOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the output. The values supported are 'json' and 'yaml'. By default, a summary is printed."))
+}
+
+func (o *statusOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *statusOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *statusOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+
+	authConfig, err := o.config.SetAuthToContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := &SessionStatus{
+		Context:     o.config.CurrentContextName(),
+		Tenant:      authConfig.Tenant,
+		Credential:  authConfig.Name,
+		Server:      authConfig.ServerURL(),
+		SessionType: apiClientSessionType,
+		Scopes:      authConfig.Scopes,
+	}
+
+	if authConfig.User {
+		status.SessionType = userSessionType
+	}
+
+	if authConfig.Client != nil {
+		status.ClientID = authConfig.Client.ClientID
+	}
+
+	if !authConfig.Expiry.IsZero() {
+		expiry := authConfig.Expiry
+		status.Expiry = &expiry
+	}
+
+	c := auth.NewTokenClient()
+	claims, err := c.Introspect(ctx, authConfig)
+	if err != nil {
+		return err
+	}
+
+	o.populateFromClaims(status, claims)
+	if authConfig.User && status.Active {
+		// the userinfo is informational, so failures are not fatal
+		if userinfo, err := c.GetUserinfo(ctx, authConfig); err != nil {
+			vc.Logger.Warnf("unable to get the userinfo; err=%v", err)
+		} else {
+			status.Userinfo = userinfo
+		}
+	}
+
+	switch o.output {
+	case "json":
+		cmdutil.WriteAsJSON(cmd, status, cmd.OutOrStdout())
+	case "yaml":
+		cmdutil.WriteAsYAML(cmd, status, cmd.OutOrStdout())
+	default:
+		return o.printSummary(cmd, status)
+	}
+
+	return nil
+}
+
+func (o *statusOptions) populateFromClaims(status *SessionStatus, claims map[string]interface{}) {
+	status.Active, _ = claims["active"].(bool)
+	if !status.Active {
+		return
+	}
+
+	status.Subject, _ = claims["sub"].(string)
+	if clientID, ok := claims["client_id"].(string); ok {
+		status.ClientID = clientID
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		expiry := time.Unix(int64(exp), 0).UTC()
+		status.Expiry = &expiry
+	}
+
+	if scopes := auth.ClaimAsStrings(claims, "scope"); len(scopes) > 0 {
+		status.Scopes = scopes
+	}
+
+	status.Entitlements = auth.ClaimAsStrings(claims, "entitlements")
+}
+
+func (o *statusOptions) printSummary(cmd *cobra.Command, status *SessionStatus) error {
+	expiry := ""
+	if status.Expiry != nil {
+		expiry = fmt.Sprintf("%s (in %s)", status.Expiry.Local().Format(time.RFC1123), time.Until(*status.Expiry).Round(time.Second))
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 10, 1, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Context:\t%s\n", status.Context)
+	_, _ = fmt.Fprintf(w, "Tenant:\t%s\n", status.Tenant)
+	_, _ = fmt.Fprintf(w, "Credential:\t%s\n", status.Credential)
+	_, _ = fmt.Fprintf(w, "Server:\t%s\n", status.Server)
+	_, _ = fmt.Fprintf(w, "Active:\t%t\n", status.Active)
+	_, _ = fmt.Fprintf(w, "Session type:\t%s\n", status.SessionType)
+	_, _ = fmt.Fprintf(w, "Subject:\t%s\n", status.Subject)
+	_, _ = fmt.Fprintf(w, "Client ID:\t%s\n", status.ClientID)
+	_, _ = fmt.Fprintf(w, "Expires:\t%s\n", expiry)
+	_, _ = fmt.Fprintf(w, "Scopes:\t%s\n", strings.Join(status.Scopes, " "))
+	_, _ = fmt.Fprintf(w, "Entitlements:\t%s\n", strings.Join(status.Entitlements, " "))
+
+	return w.Flush()
+}
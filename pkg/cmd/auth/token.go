@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"io"
+	"time"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	tokenUsage         = "token [flags]"
+	tokenMessagePrefix = "AuthToken"
+)
+
+var (
+	tokenLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(tokenMessagePrefix, `
+		Print the access token of the current context as a JSON credential.
+
+The command is meant to be called by other tools as a credential process, such as curl wrappers,
+Postman pre-request scripts or Terraform runs. The output is a JSON object with "access_token",
+"expires_at" and "tenant". The expiry is omitted if it is not known.
+
+The token is renewed if it has expired, like for any other command, and the renewed token is saved.
+Use "--min-ttl" to renew the token if it expires within the duration, so that the caller can use
+it for as long as it needs to. Errors are written to stderr and the command exits with a non-zero code.`))
+
+	tokenExamples = templates.Examples(cmdutil.TranslateExamples(tokenMessagePrefix, `
+		# Print the token of the current context
+		verifyctl auth token
+
+		# Call an API with curl using a token that is valid for at least 5 minutes
+		curl -H "Authorization: Bearer $(verifyctl auth token --min-ttl=5m | jq -r .access_token)" https://abc.verify.ibm.com/v2.0/Users
+
+		# Print the token of the "admin" credential of the "prod" context
+		verifyctl auth token --context=prod --credential=admin`))
+)
+
+type tokenOptions struct {
+	minTTL time.Duration
+
+	config *config.CLIConfig
+}
+
+// TokenCredential is the credential printed for external tools.
+type TokenCredential struct {
+	AccessToken string     `json:"access_token"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Tenant      string     `json:"tenant"`
+}
+
+func newTokenCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &tokenOptions{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   tokenUsage,
+		Short:                 cmdutil.TranslateShortDesc(tokenMessagePrefix, "Print the access token of the current context as a JSON credential."),
+		Long:                  tokenLongDesc,
+		Example:               tokenExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *tokenOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&o.minTTL, "min-ttl", 0, i18n.Translate("Minimum time for which the token must remain valid, such as '5m'. The token is renewed if it expires sooner."))
+}
+
+func (o *tokenOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *tokenOptions) Validate(cmd *cobra.Command, args []string) error {
+	if o.minTTL < 0 {
+		return errorsx.G11NError("'min-ttl' cannot be negative.")
+	}
+
+	return nil
+}
+
+func (o *tokenOptions) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	authConfig, err := o.config.SetAuthToContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if o.minTTL > 0 && authConfig.ExpiresWithin(o.minTTL) {
+		if err := o.config.RenewAuth(ctx, authConfig); err != nil {
+			return err
+		}
+
+		// the tenant may issue tokens with a shorter lifetime
+		if authConfig.ExpiresWithin(o.minTTL) {
+			return errorsx.G11NError("The token expires in %s, which is less than the minimum TTL of %s.", time.Until(authConfig.Expiry).Round(time.Second), o.minTTL)
+		}
+	}
+
+	credential := &TokenCredential{
+		AccessToken: authConfig.Token,
+		Tenant:      authConfig.Tenant,
+	}
+
+	if !authConfig.Expiry.IsZero() {
+		expiresAt := authConfig.Expiry.UTC()
+		credential.ExpiresAt = &expiresAt
+	}
+
+	cmdutil.WriteAsJSON(cmd, credential, cmd.OutOrStdout())
+	return nil
+}
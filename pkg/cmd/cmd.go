@@ -4,11 +4,17 @@ import (
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/api"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/apply"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/auth"
+	configcmd "github.com/ibm-verify/verifyctl/pkg/cmd/config"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/create"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/delete"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/diff"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/export"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/get"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/logs"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/proxy"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/replace"
 	"github.com/ibm-verify/verifyctl/pkg/config"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
@@ -24,6 +30,10 @@ const (
 )
 
 func NewRootCmd(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	contextName := ""
+	credentialName := ""
+	credentials := newCredentialOverrides()
+
 	// cmd represents the base command when called without any subcommands
 	cmd := &cobra.Command{
 		Use:   "verifyctl",
@@ -31,19 +41,44 @@ func NewRootCmd(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command
 		Long: templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `verifyctl controls the IBM Security Verify tenant.
 
   Find more information at: https://github.com/ibm-verify/verifyctl`)),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if _, ok := cmd.Annotations[configcmd.AllowInvalidConfigAnnotation]; !ok {
+				cmdutil.ExitOnError(cmd, config.LoadError())
+			}
+
+			cmdutil.ExitOnError(cmd, config.SetContextOverride(contextName))
+			config.SetCredentialOverrides(credentials)
+			config.SetCredentialOverride(credentialName)
+		},
 	}
 
 	cmd.SetOut(streams)
 	cmd.SetErr(streams)
 	cmd.SetIn(streams)
 
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", i18n.Translate("Name of the context to use for this command, instead of the current context."))
+	cmd.PersistentFlags().StringVar(&credentialName, "credential", "", i18n.Translate("Name of the saved credential of the tenant to use for this command. By default, the least privileged credential that is allowed to run the command is used. With 'auth', the name to save the credential under."))
+	cmd.PersistentFlags().StringVar(&credentials.Tenant, "tenant", "", i18n.Translate("Tenant to use for this command. Overrides the VERIFY_TENANT environment variable and the tenant of the current context."))
+	cmd.PersistentFlags().StringVar(&credentials.Token, "token", "", i18n.Translate("Access token to use for this command. The token is not saved. Overrides the VERIFY_TOKEN environment variable."))
+	cmd.PersistentFlags().StringVar(&credentials.ClientID, "client-id", "", i18n.Translate("Client ID of an API client used to get a token for this command. The client secret is read from the VERIFY_CLIENT_SECRET environment variable. The token is not saved."))
+	cmd.PersistentFlags().StringVar(&credentials.PrivateKey, "private-key", "", i18n.Translate("Private key in JWK or PEM format, or '@' followed by the path to the file, used with '--client-id' for 'private_key_jwt' client authentication."))
+	cmd.PersistentFlags().StringVar(&credentials.Server, "server", "", i18n.Translate("Base URL of the tenant APIs, used with '--token' or '--client-id', such as 'http://localhost:8080'. Overrides the VERIFY_SERVER environment variable."))
+
 	// add commands
 	cmd.AddCommand(auth.NewCommand(config, streams, basicGroupID))
+	cmd.AddCommand(auth.NewLogoutCommand(config, streams, basicGroupID))
+	cmd.AddCommand(configcmd.NewCommand(config, streams, basicGroupID))
 	cmd.AddCommand(get.NewCommand(config, streams, resourceGroupID))
 	cmd.AddCommand(create.NewCommand(config, streams, resourceGroupID))
 	cmd.AddCommand(replace.NewCommand(config, streams, resourceGroupID))
+	cmd.AddCommand(apply.NewCommand(config, streams, resourceGroupID))
+	cmd.AddCommand(diff.NewCommand(config, streams, resourceGroupID))
+	cmd.AddCommand(export.NewCommand(config, streams, resourceGroupID))
+	cmd.AddCommand(apply.NewImportCommand(config, streams, resourceGroupID))
 	cmd.AddCommand(delete.NewCommand(config, streams, resourceGroupID))
 	cmd.AddCommand(logs.NewCommand(config, streams, debugGroupID))
+	cmd.AddCommand(api.NewCommand(config, streams, debugGroupID))
+	cmd.AddCommand(proxy.NewCommand(config, streams, debugGroupID))
 
 	// add groups
 	groups := []*cobra.Group{
@@ -65,3 +100,7 @@ func NewRootCmd(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command
 
 	return cmd
 }
+
+func newCredentialOverrides() *config.CredentialOverrides {
+	return &config.CredentialOverrides{}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"io"
+
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	usage         = "config SUBCOMMAND [flags]"
+	messagePrefix = "Config"
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Modify the verifyctl configuration file using subcommands.
+
+Each successful 'auth' creates a named context that points at the tenant and its credentials.
+The current context is used by all commands, unless overridden using the global '--context' flag.
+
+The configuration file is located in your home directory under ".verify/config".`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# List all the contexts
+		verifyctl config get-contexts
+
+		# Switch to the context named "staging"
+		verifyctl config use-context staging
+
+		# Run a single command against another context
+		verifyctl get users --context=prod
+
+		# Check the configuration file for problems
+		verifyctl config validate`))
+)
+
+type options struct {
+	config *config.CLIConfig
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Modify the verifyctl configuration file."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	// add sub commands
+	cmd.AddCommand(newGetContextsCommand(config, streams))
+	cmd.AddCommand(newUseContextCommand(config, streams))
+	cmd.AddCommand(newRenameContextCommand(config, streams))
+	cmd.AddCommand(newDeleteContextCommand(config, streams))
+	cmd.AddCommand(newSetCredentialStoreCommand(config, streams))
+	cmd.AddCommand(newViewCommand(config, streams))
+	cmd.AddCommand(newValidateCommand(config, streams))
+
+	return cmd
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"io"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	deleteContextUsage         = "delete-context CONTEXT_NAME"
+	deleteContextMessagePrefix = "ConfigDeleteContext"
+)
+
+var (
+	deleteContextLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(deleteContextMessagePrefix, `
+		Delete a context from the configuration file.
+
+The saved credentials of the tenant are also removed if no other context refers to the tenant.
+The tokens are not revoked on the tenant.`))
+
+	deleteContextExamples = templates.Examples(cmdutil.TranslateExamples(deleteContextMessagePrefix, `
+		# Delete the context named "staging"
+		verifyctl config delete-context staging`))
+)
+
+type deleteContextOptions struct {
+	options
+	name string
+}
+
+func newDeleteContextCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &deleteContextOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   deleteContextUsage,
+		Short:                 cmdutil.TranslateShortDesc(deleteContextMessagePrefix, "Delete a context from the configuration file."),
+		Long:                  deleteContextLongDesc,
+		Example:               deleteContextExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	return cmd
+}
+
+func (o *deleteContextOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.name = args[0]
+	}
+
+	return nil
+}
+
+func (o *deleteContextOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.name) == 0 {
+		return errorsx.G11NError("Context name is required.")
+	}
+
+	return nil
+}
+
+func (o *deleteContextOptions) Run(cmd *cobra.Command, args []string) error {
+	err := o.config.Update(func(c *config.CLIConfig) error {
+		return c.DeleteContext(o.name)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Deleted context \"%s\".", o.name))
+	return nil
+}
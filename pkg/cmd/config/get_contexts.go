@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	getContextsUsage         = "get-contexts [flags]"
+	getContextsMessagePrefix = "ConfigGetContexts"
+)
+
+var (
+	getContextsLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(getContextsMessagePrefix, `
+		Display the contexts available in the configuration file. The current context is marked with '*'.`))
+
+	getContextsExamples = templates.Examples(cmdutil.TranslateExamples(getContextsMessagePrefix, `
+		# List all the contexts
+		verifyctl config get-contexts
+
+		# List all the contexts in YAML format
+		verifyctl config get-contexts -o=yaml`))
+)
+
+type getContextsOptions struct {
+	options
+	output string
+}
+
+func newGetContextsCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &getContextsOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   getContextsUsage,
+		Short:                 cmdutil.TranslateShortDesc(getContextsMessagePrefix, "Display the contexts available in the configuration file."),
+		Long:                  getContextsLongDesc,
+		Example:               getContextsExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *getContextsOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the output. The values supported are 'json' and 'yaml'. By default, a table is printed."))
+}
+
+func (o *getContextsOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *getContextsOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *getContextsOptions) Run(cmd *cobra.Command, args []string) error {
+	switch o.output {
+	case "json":
+		cmdutil.WriteAsJSON(cmd, o.config.Contexts, cmd.OutOrStdout())
+		return nil
+	case "yaml":
+		cmdutil.WriteAsYAML(cmd, o.config.Contexts, cmd.OutOrStdout())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 10, 1, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CURRENT\tNAME\tTENANT")
+	for _, c := range o.config.Contexts {
+		current := ""
+		if c.Name == o.config.CurrentContext {
+			current = "*"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", current, c.Name, c.Tenant)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"io"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	renameContextUsage         = "rename-context CONTEXT_NAME NEW_NAME"
+	renameContextMessagePrefix = "ConfigRenameContext"
+)
+
+var (
+	renameContextLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(renameContextMessagePrefix, `
+		Rename a context in the configuration file. If the context is the current context, it remains current.`))
+
+	renameContextExamples = templates.Examples(cmdutil.TranslateExamples(renameContextMessagePrefix, `
+		# Rename the context created for a tenant to "prod"
+		verifyctl config rename-context abc.verify.ibm.com prod`))
+)
+
+type renameContextOptions struct {
+	options
+	name    string
+	newName string
+}
+
+func newRenameContextCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &renameContextOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   renameContextUsage,
+		Short:                 cmdutil.TranslateShortDesc(renameContextMessagePrefix, "Rename a context in the configuration file."),
+		Long:                  renameContextLongDesc,
+		Example:               renameContextExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	return cmd
+}
+
+func (o *renameContextOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.name = args[0]
+	}
+
+	if len(args) > 1 {
+		o.newName = args[1]
+	}
+
+	return nil
+}
+
+func (o *renameContextOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.name) == 0 || len(o.newName) == 0 {
+		return errorsx.G11NError("Both the current and the new context names are required.")
+	}
+
+	return nil
+}
+
+func (o *renameContextOptions) Run(cmd *cobra.Command, args []string) error {
+	err := o.config.Update(func(c *config.CLIConfig) error {
+		return c.RenameContext(o.name, o.newName)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Context \"%s\" renamed to \"%s\".", o.name, o.newName))
+	return nil
+}
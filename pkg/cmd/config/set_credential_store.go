@@ -0,0 +1,93 @@
+package config
+
+import (
+	"io"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	setCredentialStoreUsage         = "set-credential-store STORE"
+	setCredentialStoreMessagePrefix = "ConfigSetCredentialStore"
+)
+
+var (
+	setCredentialStoreLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(setCredentialStoreMessagePrefix, `
+		Select where tokens, refresh tokens and client secrets are saved. Existing secrets are moved to the new store.
+
+The stores supported are:
+
+  - plaintext: Secrets are saved in the configuration file as is. This is the default.
+  - encryptedFile: Secrets are saved to ".verify/credentials", encrypted with a key derived from a passphrase.
+    The passphrase is read from the VERIFY_PASSPHRASE environment variable or prompted for in a terminal.
+    It is only needed by commands that use a saved session, not by commands such as 'config view'.`))
+
+	setCredentialStoreExamples = templates.Examples(cmdutil.TranslateExamples(setCredentialStoreMessagePrefix, `
+		# Encrypt the saved secrets
+		verifyctl config set-credential-store encryptedFile
+
+		# Encrypt the saved secrets using a passphrase from the environment
+		VERIFY_PASSPHRASE=... verifyctl config set-credential-store encryptedFile`))
+)
+
+type setCredentialStoreOptions struct {
+	options
+	store string
+}
+
+func newSetCredentialStoreCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &setCredentialStoreOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   setCredentialStoreUsage,
+		Short:                 cmdutil.TranslateShortDesc(setCredentialStoreMessagePrefix, "Select where secrets are saved."),
+		Long:                  setCredentialStoreLongDesc,
+		Example:               setCredentialStoreExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	return cmd
+}
+
+func (o *setCredentialStoreOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.store = args[0]
+	}
+
+	return nil
+}
+
+func (o *setCredentialStoreOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.store) == 0 {
+		return errorsx.G11NError("Credential store is required.")
+	}
+
+	return nil
+}
+
+func (o *setCredentialStoreOptions) Run(cmd *cobra.Command, args []string) error {
+	if err := o.config.SetCredentialStore(o.store); err != nil {
+		return err
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Credential store set to \"%s\".", o.store))
+	return nil
+}
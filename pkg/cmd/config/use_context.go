@@ -0,0 +1,89 @@
+package config
+
+import (
+	"io"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	useContextUsage         = "use-context CONTEXT_NAME"
+	useContextMessagePrefix = "ConfigUseContext"
+)
+
+var (
+	useContextLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(useContextMessagePrefix, `
+		Set the current context in the configuration file. Subsequent commands use the tenant and credentials
+of this context.`))
+
+	useContextExamples = templates.Examples(cmdutil.TranslateExamples(useContextMessagePrefix, `
+		# Use the context named "staging"
+		verifyctl config use-context staging`))
+)
+
+type useContextOptions struct {
+	options
+	name string
+}
+
+func newUseContextCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &useContextOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   useContextUsage,
+		Short:                 cmdutil.TranslateShortDesc(useContextMessagePrefix, "Set the current context in the configuration file."),
+		Long:                  useContextLongDesc,
+		Example:               useContextExamples,
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"use"},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	return cmd
+}
+
+func (o *useContextOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		o.name = args[0]
+	}
+
+	return nil
+}
+
+func (o *useContextOptions) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.name) == 0 {
+		return errorsx.G11NError("Context name is required.")
+	}
+
+	return nil
+}
+
+func (o *useContextOptions) Run(cmd *cobra.Command, args []string) error {
+	err := o.config.Update(func(c *config.CLIConfig) error {
+		return c.UseContext(o.name)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Switched to context \"%s\".", o.name))
+	return nil
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	validateUsage         = "validate [flags]"
+	validateMessagePrefix = "ConfigValidate"
+
+	// AllowInvalidConfigAnnotation marks the commands that run even if the configuration
+	// file does not match the schema.
+	AllowInvalidConfigAnnotation = "verifyctl/allow-invalid-config"
+)
+
+var (
+	validateLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(validateMessagePrefix, `
+		Check the configuration file and report the problems found.
+
+The file is checked against the schema of its version. Unknown fields and kinds are rejected. The
+consistency of the content is also checked, such as duplicate tenants, contexts without a matching
+auth entry and a current tenant that has no session.
+
+Files written by older versions of verifyctl are upgraded when they are loaded. The original file is
+kept next to it with the version as a suffix, such as "config.1.0.bak".
+
+The command exits with a non-zero status if any problems are found.`))
+
+	validateExamples = templates.Examples(cmdutil.TranslateExamples(validateMessagePrefix, `
+		# Check the configuration file
+		verifyctl config validate`))
+)
+
+type validateOptions struct {
+	options
+}
+
+func newValidateCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &validateOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   validateUsage,
+		Short:                 cmdutil.TranslateShortDesc(validateMessagePrefix, "Check the configuration file and report the problems found."),
+		Long:                  validateLongDesc,
+		Example:               validateExamples,
+		DisableFlagsInUseLine: true,
+		Annotations: map[string]string{
+			AllowInvalidConfigAnnotation: "true",
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	return cmd
+}
+
+func (o *validateOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *validateOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *validateOptions) Run(cmd *cobra.Command, args []string) error {
+	var invalidConfigErr *config.InvalidConfigError
+	if err := o.config.LoadError(); errors.As(err, &invalidConfigErr) {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("The configuration file '%s' is invalid: %v", invalidConfigErr.Path, invalidConfigErr.Err))
+		return &cmdutil.ExitError{Code: 1}
+	}
+
+	problems := o.config.Validate()
+	if len(problems) == 0 {
+		cmdutil.WriteString(cmd, i18n.Translate("The configuration file is valid."))
+		return nil
+	}
+
+	for _, p := range problems {
+		cmdutil.WriteString(cmd, "- "+p)
+	}
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Problems found: %d", len(problems)))
+	return &cmdutil.ExitError{Code: 1}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+)
+
+const (
+	viewUsage         = "view [flags]"
+	viewMessagePrefix = "ConfigView"
+	redacted          = "REDACTED"
+)
+
+var (
+	viewLongDesc = templates.LongDesc(cmdutil.TranslateLongDesc(viewMessagePrefix, `
+		Display the configuration file. Tokens and client secrets are redacted unless the '--raw' flag is used.`))
+
+	viewExamples = templates.Examples(cmdutil.TranslateExamples(viewMessagePrefix, `
+		# Show the configuration
+		verifyctl config view
+
+		# Show the configuration in JSON format, including tokens
+		verifyctl config view --raw -o=json`))
+)
+
+type viewOptions struct {
+	options
+	output string
+	raw    bool
+}
+
+func newViewCommand(config *config.CLIConfig, streams io.ReadWriter) *cobra.Command {
+	o := &viewOptions{
+		options: options{
+			config: config,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   viewUsage,
+		Short:                 cmdutil.TranslateShortDesc(viewMessagePrefix, "Display the configuration file."),
+		Long:                  viewLongDesc,
+		Example:               viewExamples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *viewOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the output. The values supported are 'json' and 'yaml'. Default: 'yaml'."))
+	cmd.Flags().BoolVar(&o.raw, "raw", false, i18n.Translate("Display the tokens as is, rather than redacting them."))
+}
+
+func (o *viewOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *viewOptions) Validate(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *viewOptions) Run(cmd *cobra.Command, args []string) error {
+	view := *o.config
+	if !o.raw {
+		view.Auth = []*config.AuthConfig{}
+		for _, c := range o.config.Auth {
+			auth := *c
+			if len(auth.Token) > 0 {
+				auth.Token = redacted
+			}
+
+			if len(auth.RefreshToken) > 0 {
+				auth.RefreshToken = redacted
+			}
+
+			if auth.Client != nil {
+				client := *auth.Client
+				if len(client.ClientSecret) > 0 {
+					client.ClientSecret = redacted
+				}
+
+				// keys referenced by a file path are not sensitive
+				if len(client.PrivateKey) > 0 && !strings.HasPrefix(client.PrivateKey, "@") {
+					client.PrivateKey = redacted
+				}
+
+				auth.Client = &client
+			}
+
+			view.Auth = append(view.Auth, &auth)
+		}
+	}
+
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, view, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, view, cmd.OutOrStdout())
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
 	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
@@ -19,7 +20,6 @@ import (
 const (
 	accessPolicyUsage         = "accesspolicy [options]"
 	accessPolicyMessagePrefix = "CreateAccessPolicy"
-	accessPolicyEntitlements  = "Manage Access Policies"
 	accessPolicyResourceName  = "accesspolicy"
 )
 
@@ -103,7 +103,7 @@ func (o *accessPolicyOptions) Validate(cmd *cobra.Command, args []string) error
 
 func (o *accessPolicyOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+accessPolicyEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbCreate, entitlements.ResourceAccessPolicies))
 		return nil
 	}
 
@@ -118,7 +118,7 @@ func (o *accessPolicyOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbCreate, entitlements.ResourceAccessPolicies)
 	if err != nil {
 		return err
 	}
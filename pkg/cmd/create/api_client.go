@@ -8,6 +8,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"gopkg.in/yaml.v3"
@@ -20,7 +21,6 @@ import (
 const (
 	apiClientUsage         = "apiclient [options]"
 	apiClientMessagePrefix = "CreateApiClient"
-	apiClientEntitlements  = "Manage API Clients"
 	apiClientResourceName  = "apiclient"
 )
 
@@ -107,7 +107,7 @@ func (o *apiClientOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *apiClientOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+apiClientEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbCreate, entitlements.ResourceAPIClients))
 		return nil
 	}
 	if o.boilerplate {
@@ -121,7 +121,7 @@ func (o *apiClientOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.GetCurrentAuth()
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbCreate, entitlements.ResourceAPIClients)
 	if err != nil {
 		return err
 	}
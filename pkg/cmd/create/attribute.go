@@ -9,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -20,7 +21,6 @@ import (
 const (
 	attributeUsage         = `attribute [options]`
 	attributeMessagePrefix = "CreateAttribute"
-	attributeEntitlements  = "Manage attributes"
 	attributeResourceName  = "attribute"
 )
 
@@ -104,7 +104,7 @@ func (o *attributeOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *attributeOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+attributeEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbCreate, entitlements.ResourceAttributes))
 		return nil
 	}
 
@@ -121,7 +121,7 @@ func (o *attributeOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.GetCurrentAuth()
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbCreate, entitlements.ResourceAttributes)
 	if err != nil {
 		return err
 	}
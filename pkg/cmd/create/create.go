@@ -2,10 +2,12 @@ package create
 
 import (
 	"io"
+	"strings"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -27,6 +29,11 @@ var (
 
 JSON or YAML formats are accepted and determined based on the file extension.
 
+A file may contain several resources, as YAML documents separated by "---", as a JSON array or as a list
+printed by 'get'. When a directory is passed, its JSON and YAML files are read in lexical order, and the
+subdirectories are read too with "--recursive". Each resource is created in turn and a summary of the
+resources that succeeded and failed is printed at the end.
+
 An empty resource file can be generated using:
 
   verifyctl create [resource-type] --boilerplate
@@ -44,7 +51,10 @@ Certain resources may offer additional options and can be determined using:
 
 	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
 		# Create an application
-		verifyctl create -f=./app-1098012.json`))
+		verifyctl create -f=./app-1098012.json
+
+		# Create all the resources in a directory and its subdirectories
+		verifyctl create -f=./tenant --recursive`))
 
 	// # Create and get an attribute
 	// verifyctl create -f=./attribute.yml -o=yaml
@@ -56,6 +66,7 @@ type options struct {
 	entitlements bool
 	boilerplate  bool
 	file         string
+	recursive    bool
 	//output       string
 
 	config *config.CLIConfig
@@ -103,7 +114,8 @@ func (o *options) addCommonFlags(cmd *cobra.Command, resourceName string) {
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to the file that contains the input data. JSON and YAML formats are supported and the files are expected to be named with the appropriate extension: json, yml or yaml."))
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to the file or directory that contains the resources, or '-' to read them from stdin. JSON and YAML formats are supported and the files are expected to be named with the appropriate extension: json, yml or yaml. A file may contain several YAML documents separated by '---' or a JSON array."))
+	cmd.Flags().BoolVarP(&o.recursive, "recursive", "R", false, i18n.Translate("Read the files in the subdirectories of the directory passed to 'file'."))
 	//cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Fetches the newly created resource in the indicated format. The values supported are 'json' , 'yaml' and 'raw'. Default: 'yaml'."))
 }
 
@@ -120,17 +132,35 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return errorsx.G11NError("'file' option is required")
 	}
 
-	// read the file
-	resourceObject, err := o.readFile(cmd)
+	// read the files
+	resourceObjects, err := o.readFile(cmd)
 	if err != nil {
 		return err
 	}
 
+	return resource.RunEach(cmd, resourceObjects, func(resourceObject *resource.SourcedResourceObject) error {
+		return o.createResource(cmd, resourceObject.ResourceObject)
+	})
+}
+
+// createResource dispatches the resource to the command of its kind.
+func (o *options) createResource(cmd *cobra.Command, resourceObject *resource.ResourceObject) error {
 	if len(resourceObject.Kind) == 0 {
 		return errorsx.G11NError("No 'kind' defined. Resource type cannot be identified.")
 	}
 
-	auth, err := o.config.SetAuthToContext(cmd.Context())
+	data, err := resourceObject.DataMap()
+	if err != nil {
+		return err
+	}
+
+	var auth *config.AuthConfig
+	if resourceName, ok := entitlements.ResourceForKind(strings.TrimPrefix(resourceObject.Kind, resource.ResourceTypePrefix)); ok {
+		auth, err = entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbCreate, resourceName)
+	} else {
+		auth, err = o.config.SetAuthToContext(cmd.Context())
+	}
+
 	if err != nil {
 		return err
 	}
@@ -138,41 +168,44 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 	switch resourceObject.Kind {
 	case resource.ResourceTypePrefix + "Attribute":
 		options := &attributeOptions{}
-		err = options.createAttributeFromDataMap(cmd, resourceObject.Data.(map[string]interface{}))
+		err = options.createAttributeFromDataMap(cmd, data)
 
 	case resource.ResourceTypePrefix + "User":
 		options := &userOptions{}
-		err = options.createUserFromDataMap(cmd, resourceObject.Data.(map[string]interface{}))
+		err = options.createUserFromDataMap(cmd, data)
 
 	case resource.ResourceTypePrefix + "Group":
 		options := &groupOptions{}
-		err = options.createGroupFromDataMap(cmd, resourceObject.Data.(map[string]interface{}))
+		err = options.createGroupFromDataMap(cmd, data)
 
 	case resource.ResourceTypePrefix + "AccessPolicy":
 		options := &accessPolicyOptions{}
-		err = options.createAccessPolicyFromDataMap(cmd, resourceObject.Data.(map[string]interface{}))
+		err = options.createAccessPolicyFromDataMap(cmd, data)
 
 	case resource.ResourceTypePrefix + "IdentitySource":
 		options := &identitysourceOptions{}
-		err = options.createIdentitySourceFromDataMap(cmd, auth, resourceObject.Data.(map[string]interface{}))
+		err = options.createIdentitySourceFromDataMap(cmd, auth, data)
 
 	case resource.ResourceTypePrefix + "APIClient":
 		options := &apiClientOptions{}
-		err = options.createAPIClientFromDataMap(cmd, resourceObject.Data.(map[string]interface{}))
+		err = options.createAPIClientFromDataMap(cmd, data)
+
+	default:
+		err = errorsx.G11NError("The kind '%s' is not supported.", resourceObject.Kind)
 	}
 
 	return err
 }
 
-func (o *options) readFile(cmd *cobra.Command) (*resource.ResourceObject, error) {
+func (o *options) readFile(cmd *cobra.Command) ([]*resource.SourcedResourceObject, error) {
 	ctx := cmd.Context()
 	vc := contextx.GetVerifyContext(ctx)
 
-	resourceObject := &resource.ResourceObject{}
-	if err := resourceObject.LoadFromFile(cmd, o.file, ""); err != nil {
-		vc.Logger.Errorf("unable to read file contents into resource object; err=%v", err)
+	resourceObjects, err := resource.LoadResourceObjects(cmd, o.file, o.recursive)
+	if err != nil {
+		vc.Logger.Errorf("unable to read file contents into resource objects; err=%v", err)
 		return nil, err
 	}
 
-	return resourceObject, nil
+	return resourceObjects, nil
 }
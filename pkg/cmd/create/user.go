@@ -7,6 +7,7 @@ import (
 
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/directory"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
@@ -20,7 +21,6 @@ import (
 const (
 	userUsage         = "user [options]"
 	userMessagePrefix = "CreateUser"
-	userEntitlements  = "Manage users"
 	userResourceName  = "user"
 )
 
@@ -104,7 +104,7 @@ func (o *userOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *userOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+userEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbCreate, entitlements.ResourceUsers))
 		return nil
 	}
 
@@ -119,7 +119,7 @@ func (o *userOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbCreate, entitlements.ResourceUsers)
 	if err != nil {
 		return err
 	}
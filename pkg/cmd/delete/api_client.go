@@ -6,6 +6,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +17,6 @@ import (
 const (
 	apiclientUsage         = `apiclient [flags]`
 	apiclientMessagePrefix = "DeleteApiclient"
-	apiclientEntitlements  = "Manage apiclients"
 	apiclientResourceName  = "apiclient"
 )
 
@@ -96,11 +96,11 @@ func (o *apiclientsOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *apiclientsOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+apiclientEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbDelete, entitlements.ResourceAPIClients))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbDelete, entitlements.ResourceAPIClients)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -15,7 +16,6 @@ import (
 const (
 	groupsUsage         = `group [flags]`
 	groupsMessagePrefix = "DeleteGroup"
-	groupsEntitlements  = "Manage groups"
 	groupResourceName   = "group"
 )
 
@@ -92,11 +92,11 @@ func (o *groupsOptions) Valnameate(cmd *cobra.Command, args []string) error {
 
 func (o *groupsOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+groupsEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbDelete, entitlements.ResourceGroups))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbDelete, entitlements.ResourceGroups)
 	if err != nil {
 		return err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
 	"github.com/ibm-verify/verifyctl/pkg/module/directory"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -15,7 +16,6 @@ import (
 const (
 	identitysourcesUsage         = `identitysource [flags]`
 	identitysourcesMessagePrefix = "DeleteIdentitysource"
-	identitysourcesEntitlements  = "Manage identitysources"
 	identitysourceResourceName   = "identitysource"
 )
 
@@ -92,11 +92,11 @@ func (o *identitysourcesOptions) Validate(cmd *cobra.Command, args []string) err
 
 func (o *identitysourcesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+identitysourcesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbDelete, entitlements.ResourceIdentitySources))
 		return nil
 	}
 
-	auth, err := o.config.SetAuthToContext(cmd.Context())
+	auth, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbDelete, entitlements.ResourceIdentitySources)
 	if err != nil {
 		return err
 	}
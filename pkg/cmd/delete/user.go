@@ -7,6 +7,7 @@ import (
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -15,7 +16,6 @@ import (
 const (
 	usersUsage         = `user [flags]`
 	usersMessagePrefix = "DeleteUser"
-	usersEntitlements  = "Manage users"
 	userResourceName   = "user"
 )
 
@@ -92,11 +92,11 @@ func (o *usersOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *usersOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+usersEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbDelete, entitlements.ResourceUsers))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbDelete, entitlements.ResourceUsers)
 	if err != nil {
 		return err
 	}
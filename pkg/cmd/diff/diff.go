@@ -0,0 +1,273 @@
+package diff
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	"github.com/ibm-verify/verifyctl/pkg/module/kinds"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	diffutil "github.com/ibm-verify/verifyctl/pkg/util/diff"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	usage         = "diff -f=FILENAME [options]"
+	messagePrefix = "Diff"
+
+	contextLines = 3
+
+	// the exit codes are those of diff(1)
+	differencesExitCode = 1
+	errorExitCode       = 2
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Compare Verify resources in files with the tenant.
+
+Each resource is fetched from the tenant by its name, as with 'apply': "userName" for users, "displayName"
+for groups, "name" for attributes and access policies, "instanceName" for identity sources and
+"clientName" for API clients. Fields set by the tenant, such as IDs, metadata and timestamps, are not
+compared, and neither are the fields that are not in the file.
+
+By default, the differences are printed as a unified diff of the YAML of the resource on the tenant and
+in the file. Use "--output=fields" to list the fields that differ instead, with their value on the tenant
+and in the file. Resources that do not exist on the tenant are shown in full.
+
+Group members are named by their "userName" in the files and are compared using the user IDs.
+
+The command exits with the code 1 if any resource differs from the tenant, and with the code 2 if any
+resource could not be compared, so that it can be used to detect changes in scripts and pipelines.`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# Show how the users in a file differ from the tenant
+		verifyctl diff -f=./users.yaml
+
+		# List the fields that differ for all the resources in a directory and its subdirectories
+		verifyctl diff -f=./tenant --recursive --output=fields`))
+)
+
+type options struct {
+	file      string
+	recursive bool
+	output    string
+
+	config *config.CLIConfig
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &options{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Compare Verify resources in files with the tenant."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnErrorWithCode(cmd, o.Complete(cmd, args), errorExitCode)
+			cmdutil.ExitOnErrorWithCode(cmd, o.Validate(cmd, args), errorExitCode)
+			cmdutil.ExitOnErrorWithCode(cmd, o.Run(cmd, args), errorExitCode)
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.file, "file", "f", "", i18n.Translate("Path to the file or directory that contains the resources, or '-' to read them from stdin. JSON and YAML formats are supported and the files are expected to be named with the appropriate extension: json, yml or yaml."))
+	cmd.Flags().BoolVarP(&o.recursive, "recursive", "R", false, i18n.Translate("Read the files in the subdirectories of the directory passed to 'file'."))
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the differences. The values supported are 'unified' and 'fields'. Default: 'unified'."))
+}
+
+func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	if len(o.output) == 0 {
+		o.output = "unified"
+	}
+
+	return nil
+}
+
+func (o *options) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.file) == 0 {
+		return errorsx.G11NError("'file' option is required")
+	}
+
+	if o.output != "unified" && o.output != "fields" {
+		return errorsx.G11NError("unsupported output format '%s'. Use 'unified' or 'fields'.", o.output)
+	}
+
+	return nil
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	resourceObjects, err := resource.LoadResourceObjects(cmd, o.file, o.recursive)
+	if err != nil {
+		return err
+	}
+
+	differ, failed := 0, 0
+	for _, resourceObject := range resourceObjects {
+		changed, err := o.diffResource(cmd, resourceObject)
+		if err != nil {
+			failed++
+			_, _ = io.WriteString(cmd.ErrOrStderr(), i18n.TranslateWithArgs("Error comparing %s: %v", describe(resourceObject), err)+"\n")
+			continue
+		}
+
+		if changed {
+			differ++
+		}
+	}
+
+	if failed > 0 {
+		return errorsx.G11NError("%d of %d resources could not be compared.", failed, len(resourceObjects))
+	}
+
+	if differ > 0 {
+		return &cmdutil.ExitError{
+			Code: differencesExitCode,
+			Err:  errorsx.G11NError("%d of %d resources differ from the tenant.", differ, len(resourceObjects)),
+		}
+	}
+
+	return nil
+}
+
+// diffResource writes the differences between the resource and the tenant, and returns
+// true if there are any.
+func (o *options) diffResource(cmd *cobra.Command, resourceObject *resource.SourcedResourceObject) (bool, error) {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+	if len(resourceObject.Kind) == 0 {
+		return false, errorsx.G11NError("No 'kind' defined. Resource type cannot be identified.")
+	}
+
+	kind, ok := kinds.ForKind(strings.TrimPrefix(resourceObject.Kind, resource.ResourceTypePrefix))
+	if !ok {
+		return false, errorsx.G11NError("The kind '%s' cannot be compared.", resourceObject.Kind)
+	}
+
+	data, err := resourceObject.DataMap()
+	if err != nil {
+		return false, err
+	}
+
+	name := kind.NameOf(data)
+	if len(name) == 0 {
+		return false, errorsx.G11NError("The '%s' field is required to compare the resource.", kind.Key)
+	}
+
+	auth, err := entitlements.SetAuthToContext(ctx, o.config, entitlements.VerbGet, kind.Resource)
+	if err != nil {
+		return false, err
+	}
+
+	live, err := kind.Get(ctx, auth, name)
+	if err != nil {
+		vc.Logger.Errorf("unable to get the resource; kind=%s, name=%s, err=%v", resourceObject.Kind, name, err)
+		return false, err
+	}
+
+	desired, err := kind.Resolve(ctx, auth, data)
+	if err != nil {
+		return false, err
+	}
+
+	if desired, err = kind.Normalize(desired); err != nil {
+		return false, err
+	}
+
+	liveName := "tenant/" + resourceObject.Kind + "/" + name
+	if live == nil {
+		liveName = "/dev/null"
+		live = map[string]interface{}{}
+	} else if live, err = kind.Normalize(live); err != nil {
+		return false, err
+	}
+
+	changes := kind.Changes(live, desired)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	if o.output == "fields" {
+		header := describe(resourceObject)
+		if liveName == "/dev/null" {
+			header += " " + i18n.Translate("does not exist on the tenant")
+		}
+
+		cmdutil.WriteString(cmd, header)
+		for _, change := range changes {
+			if change.Live == nil {
+				cmdutil.WriteString(cmd, "  + "+change.Path+": "+encode(change.Desired))
+			} else {
+				cmdutil.WriteString(cmd, "  ~ "+change.Path+": "+encode(change.Live)+" -> "+encode(change.Desired))
+			}
+		}
+
+		return true, nil
+	}
+
+	liveLines := []string{}
+	if liveName != "/dev/null" {
+		liveLines, err = yamlLines(kind.Trim(live, desired))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	desiredLines, err := yamlLines(desired)
+	if err != nil {
+		return false, err
+	}
+
+	cmdutil.WriteAsBinary(cmd, []byte(diffutil.Unified(liveName, resourceObject.Source, liveLines, desiredLines, contextLines)), cmd.OutOrStdout())
+	return true, nil
+}
+
+// describe returns the kind and name of the resource, and the file it was read from.
+func describe(resourceObject *resource.SourcedResourceObject) string {
+	s := resourceObject.Kind
+	if name := resourceObject.Name(); len(name) > 0 {
+		s += " '" + name + "'"
+	}
+
+	return s + " (" + resourceObject.Source + ")"
+}
+
+func encode(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "?"
+	}
+
+	return string(b)
+}
+
+func yamlLines(data map[string]interface{}) ([]string, error) {
+	b, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffutil.Lines(string(b)), nil
+}
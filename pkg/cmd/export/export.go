@@ -0,0 +1,396 @@
+package export
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/config/branding"
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	"github.com/ibm-verify/verifyctl/pkg/module/kinds"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	usage         = "export --dir=DIRECTORY [options]"
+	messagePrefix = "Export"
+
+	// the resources may hold personal data, so only the owner can read them
+	dirPerm = 0700
+
+	themePageSize = 100
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Export the resources of the tenant to a directory.
+
+Each resource is written to its own YAML file, named after the resource, in a subdirectory named after its
+kind: "attributes", "users", "groups", "identitysources", "apiclients" and "accesspolicies". The files can
+be passed to 'create', 'apply' and 'diff' with the "--recursive" flag.
+
+The fields set by the tenant, such as IDs, metadata and timestamps, and the secrets, such as passwords and
+client secrets, are not exported. Group members and owners are named by their "userName", and member groups
+by their "displayName". The resources and their fields are sorted, so that two exports of the same tenant
+are identical and can be compared or versioned. The files and directories written can only be read by the
+current user, as the resources may hold personal data.
+
+The themes are unpacked into the "themes" directory, with one subdirectory per theme ID. The directory
+holds a ".verifyignore" file, so that its files are not read as resources.
+
+The subdirectory of each kind is replaced by the export. Kinds that are not exported, such as with
+"--exclude", are left as they are. If a kind cannot be exported, the others are still exported and the
+command fails at the end.`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# Export every resource of the tenant
+		verifyctl export --dir=./tenant
+
+		# Export the users and groups only
+		verifyctl export --dir=./tenant --kinds=users,groups
+
+		# Export every resource except the themes
+		verifyctl export --dir=./tenant --exclude=themes`))
+)
+
+type options struct {
+	dir     string
+	kinds   []string
+	exclude []string
+
+	config *config.CLIConfig
+}
+
+// exportResult is the number of resources exported for a kind, or the reason it failed.
+type exportResult struct {
+	resource string
+	count    int
+	err      error
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &options{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Export the resources of the tenant to a directory."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.dir, "dir", "", i18n.Translate("Path to the directory the resources are written to. It is created if it does not exist."))
+	cmd.Flags().StringSliceVar(&o.kinds, "kinds", nil, i18n.Translate("Comma-separated list of the kinds to export, such as 'users,groups'. Default: all the kinds."))
+	cmd.Flags().StringSliceVar(&o.exclude, "exclude", nil, i18n.Translate("Comma-separated list of the kinds not to export, such as 'themes'."))
+}
+
+func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	var err error
+	if o.kinds, err = normalizeResources(o.kinds); err != nil {
+		return err
+	}
+
+	o.exclude, err = normalizeResources(o.exclude)
+	return err
+}
+
+func (o *options) Validate(cmd *cobra.Command, args []string) error {
+	if len(o.dir) == 0 {
+		return errorsx.G11NError("'dir' option is required")
+	}
+
+	if len(o.resources()) == 0 {
+		return errorsx.G11NError("No kinds to export.")
+	}
+
+	return nil
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	if err := os.MkdirAll(o.dir, dirPerm); err != nil {
+		return err
+	}
+
+	results := []*exportResult{}
+	for _, r := range o.resources() {
+		result := &exportResult{resource: r}
+		if r == entitlements.ResourceThemes {
+			result.count, result.err = o.exportThemes(cmd)
+		} else {
+			kind, _ := forResource(r)
+			result.count, result.err = o.exportKind(cmd, kind)
+		}
+
+		if result.err != nil {
+			_, _ = io.WriteString(cmd.ErrOrStderr(), i18n.TranslateWithArgs("Error exporting %s: %v", r, result.err)+"\n")
+		}
+
+		results = append(results, result)
+	}
+
+	failed := 0
+	cmdutil.WriteString(cmd, "")
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Exported to '%s':", o.dir))
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			cmdutil.WriteString(cmd, "  "+i18n.Translate("FAILED")+"\t"+result.resource)
+		} else {
+			cmdutil.WriteString(cmd, "  "+strconv.Itoa(result.count)+"\t"+result.resource)
+		}
+	}
+
+	if failed > 0 {
+		return errorsx.G11NError("%d of %d kinds could not be exported.", failed, len(results))
+	}
+
+	return nil
+}
+
+// resources returns the resources to export, in the order of the registry.
+func (o *options) resources() []string {
+	result := []string{}
+	for _, r := range supportedResources() {
+		if len(o.kinds) > 0 && !slices.Contains(o.kinds, r) {
+			continue
+		}
+
+		if slices.Contains(o.exclude, r) {
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// exportKind writes a file for each resource of the kind and returns their number.
+func (o *options) exportKind(cmd *cobra.Command, kind *kinds.Kind) (int, error) {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+	auth, err := entitlements.SetAuthToContext(ctx, o.config, entitlements.VerbGet, kind.Resource)
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := kind.List(ctx, auth)
+	if err != nil {
+		vc.Logger.Errorf("unable to list the resources; kind=%s, err=%v", kind.Name, err)
+		return 0, err
+	}
+
+	objects := []*resource.ResourceObject{}
+	for _, item := range items {
+		data, err := kind.Export(ctx, auth, item)
+		if err != nil {
+			vc.Logger.Errorf("unable to export the resource; kind=%s, name=%s, err=%v", kind.Name, kind.NameOf(item), err)
+			return 0, err
+		}
+
+		objects = append(objects, &resource.ResourceObject{
+			Kind:       resource.ResourceTypePrefix + kind.Name,
+			APIVersion: kind.APIVersion,
+			Metadata: &resource.ResourceObjectMetadata{
+				Name:        kind.NameOf(data),
+				LastApplied: kind.LastApplied(item),
+			},
+			Data: data,
+		})
+	}
+
+	// the directory is only replaced once every resource could be read, so that resources
+	// deleted from the tenant are removed from the export
+	dir, err := o.replaceDir(kind.Resource)
+	if err != nil {
+		return 0, err
+	}
+
+	names := map[string]bool{}
+	for _, obj := range objects {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return 0, err
+		}
+
+		file := filepath.Join(dir, fileName(obj.Metadata.Name, names)+".yaml")
+		if err := cmdutil.WritePrivateFile(file, b); err != nil {
+			vc.Logger.Errorf("unable to write the file; filename=%s, err=%v", file, err)
+			return 0, err
+		}
+	}
+
+	return len(objects), nil
+}
+
+// exportThemes unpacks each theme into a directory named after its ID and returns the
+// number of themes.
+func (o *options) exportThemes(cmd *cobra.Command) (int, error) {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+	if _, err := entitlements.SetAuthToContext(ctx, o.config, entitlements.VerbGet, entitlements.ResourceThemes); err != nil {
+		return 0, err
+	}
+
+	c := branding.NewThemeClient()
+	themes := []*branding.Theme{}
+	for page := 1; ; page++ {
+		response, _, err := c.ListThemes(ctx, 0, page, themePageSize)
+		if err != nil {
+			vc.Logger.Errorf("unable to list the themes; err=%v", err)
+			return 0, err
+		}
+
+		themes = append(themes, response.Themes...)
+		if len(response.Themes) < themePageSize {
+			break
+		}
+	}
+
+	zips := map[string][]byte{}
+	for _, theme := range themes {
+		b, _, err := c.GetTheme(ctx, theme.ThemeID, false)
+		if err != nil {
+			vc.Logger.Errorf("unable to get the theme; themeID=%s, err=%v", theme.ThemeID, err)
+			return 0, err
+		}
+
+		zips[theme.ThemeID] = b
+	}
+
+	dir, err := o.replaceDir(entitlements.ResourceThemes)
+	if err != nil {
+		return 0, err
+	}
+
+	// the theme files are not resources
+	if err := cmdutil.WritePrivateFile(filepath.Join(dir, resource.IgnoreFileName), nil); err != nil {
+		return 0, err
+	}
+
+	for _, theme := range themes {
+		if err := cmdutil.UnpackZipToDirectory(cmd, zips[theme.ThemeID], filepath.Join(dir, fileName(theme.ThemeID, nil))); err != nil {
+			vc.Logger.Errorf("unable to unpack the theme; themeID=%s, err=%v", theme.ThemeID, err)
+			return 0, err
+		}
+	}
+
+	return len(themes), nil
+}
+
+// replaceDir removes the directory of the resource and creates it empty.
+func (o *options) replaceDir(name string) (string, error) {
+	dir := filepath.Join(o.dir, name)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// supportedResources returns the resources that can be exported.
+func supportedResources() []string {
+	result := []string{}
+	for _, kind := range kinds.All() {
+		result = append(result, kind.Resource)
+	}
+
+	return append(result, entitlements.ResourceThemes)
+}
+
+func forResource(name string) (*kinds.Kind, bool) {
+	for _, kind := range kinds.All() {
+		if kind.Resource == name {
+			return kind, true
+		}
+	}
+
+	return nil, false
+}
+
+// normalizeResources returns the resources of the values, which may be resources, their
+// singular names or kinds, such as "users", "user" or "IBMVerifyUser".
+func normalizeResources(values []string) ([]string, error) {
+	result := []string{}
+	for _, value := range values {
+		r, ok := entitlements.ResourceForKind(strings.TrimPrefix(value, resource.ResourceTypePrefix))
+		if !ok {
+			if rule, err := entitlements.Lookup(entitlements.VerbGet, value); err == nil {
+				r, ok = rule.Resource, true
+			}
+		}
+
+		if !ok || !slices.Contains(supportedResources(), r) {
+			return nil, errorsx.G11NError("The kind '%s' cannot be exported. Use any of: %s.", value, strings.Join(supportedResources(), ", "))
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// fileName returns a name that can be used for a file, without the characters that are
+// not allowed on some systems. A suffix is added if the name is already used.
+func fileName(name string, used map[string]bool) string {
+	sb := &strings.Builder{}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '@', c == '-':
+			sb.WriteRune(c)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+
+	s := strings.TrimLeft(sb.String(), ".")
+	if len(s) == 0 {
+		s = "_"
+	}
+
+	if used == nil {
+		return s
+	}
+
+	// file names are compared without case for the systems that ignore it
+	unique := s
+	for i := 2; used[strings.ToLower(unique)]; i++ {
+		unique = s + "-" + strconv.Itoa(i)
+	}
+
+	used[strings.ToLower(unique)] = true
+	return unique
+}
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
@@ -8,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	accessPoliciesUsage         = `accesspolicies [flags]`
 	accessPoliciesMessagePrefix = "GetAccesspolicies"
-	accessPoliciesEntitlements  = "Manage accessPolicies"
 	accessPolicyResourceName    = "accesspolicy"
 )
 
@@ -88,6 +89,10 @@ func (o *accessPoliciesOptions) Validate(cmd *cobra.Command, args []string) erro
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "accesspolicy" && o.accessPolicyID == "" {
 		return errorsx.G11NError("'accessPolicyID' flag is required.")
@@ -97,17 +102,22 @@ func (o *accessPoliciesOptions) Validate(cmd *cobra.Command, args []string) erro
 
 func (o *accessPoliciesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+accessPoliciesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceAccessPolicies))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "accesspolicy" || len(o.accessPolicyID) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceAccessPolicies, "5.0", o.fetchAccessPolicies(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceAccessPolicies)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "accesspolicy" || len(o.accessPolicyID) > 0 {
+	if single {
 		// deal with single accessPolicy
 		return o.handleSingleAccesspolicy(cmd, args)
 	}
@@ -128,17 +138,7 @@ func (o *accessPoliciesOptions) handleSingleAccesspolicy(cmd *cobra.Command, _ [
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "AccessPolicy",
-		APIVersion: "5.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			ID:   ap.ID,
-			Name: ap.Name,
-			URI:  uri,
-		},
-		Data: ap,
-	}
-
+	resourceObj := accessPolicyResourceObject(ap, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -150,8 +150,7 @@ func (o *accessPoliciesOptions) handleSingleAccesspolicy(cmd *cobra.Command, _ [
 
 func (o *accessPoliciesOptions) handleAccesspolicyList(cmd *cobra.Command, _ []string) error {
 
-	c := security.NewAccessPolicyClient()
-	accessPolicies, uri, err := c.GetAccessPolicies(cmd.Context(), 0, 0)
+	accessPolicies, resourceObj, err := o.listAccessPolicies(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -161,6 +160,58 @@ func (o *accessPoliciesOptions) handleAccesspolicyList(cmd *cobra.Command, _ []s
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *accessPoliciesOptions) fetchAccessPolicies(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		c := security.NewAccessPolicyClient()
+		if single {
+			ap, uri, err := c.GetAccessPolicy(ctx, o.accessPolicyID)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{accessPolicyResourceObject(ap, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listAccessPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func accessPolicyResourceObject(ap *security.Policy, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "AccessPolicy",
+		APIVersion: "5.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			ID:   ap.ID,
+			Name: ap.Name,
+			URI:  uri,
+		},
+		Data: ap,
+	}
+}
+
+// listAccessPolicies gets the access policies and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *accessPoliciesOptions) listAccessPolicies(ctx context.Context) (*security.PolicyListResponse, *resource.ResourceObjectList, error) {
+	c := security.NewAccessPolicyClient()
+	accessPolicies, uri, err := c.GetAccessPolicies(ctx, 0, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, ap := range accessPolicies.Policies {
 		items = append(items, &resource.ResourceObject{
@@ -174,7 +225,7 @@ func (o *accessPoliciesOptions) handleAccesspolicyList(cmd *cobra.Command, _ []s
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return accessPolicies, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "5.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -182,13 +233,5 @@ func (o *accessPoliciesOptions) handleAccesspolicyList(cmd *cobra.Command, _ []s
 			Total: int(accessPolicies.Total),
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
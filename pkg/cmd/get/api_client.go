@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
@@ -8,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	apiclientUsage          = `apiclients [flags]`
 	apiclientsMessagePrefix = "Getapiclients"
-	apiclientsEntitlements  = "Manage apiclients"
 	apiclientResourceName   = "apiclient"
 )
 
@@ -92,6 +93,10 @@ func (o *apiclientsOptions) Validate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "apiclient" && o.name == "" && o.id == "" {
 		return errorsx.G11NError("either 'clientName' or 'clientID' flag is required.")
@@ -104,16 +109,21 @@ func (o *apiclientsOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *apiclientsOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+apiclientsEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceAPIClients))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "apiclient" || len(o.name) > 0 || len(o.id) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceAPIClients, "1.0", o.fetchAPIClients(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceAPIClients)
 	if err != nil {
 		return err
 	}
 
-	if cmd.CalledAs() == "apiclient" || len(o.name) > 0 || len(o.id) > 0 {
+	if single {
 		return o.handleSingleAPIClient(cmd, args)
 	}
 
@@ -122,16 +132,7 @@ func (o *apiclientsOptions) Run(cmd *cobra.Command, args []string) error {
 
 func (o *apiclientsOptions) handleSingleAPIClient(cmd *cobra.Command, _ []string) error {
 
-	c := security.NewAPIClient()
-	var apic *security.APIClientConfig
-	var uri string
-	var err error
-
-	if o.id != "" {
-		apic, uri, err = c.GetAPIClientByID(cmd.Context(), o.id)
-	} else {
-		apic, uri, err = c.GetAPIClientByName(cmd.Context(), o.name)
-	}
+	apic, uri, err := o.getAPIClient(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -141,17 +142,7 @@ func (o *apiclientsOptions) handleSingleAPIClient(cmd *cobra.Command, _ []string
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "APIClient",
-		APIVersion: "1.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			UID:  *apic.ClientID,
-			Name: apic.ClientName,
-			URI:  uri,
-		},
-		Data: apic,
-	}
-
+	resourceObj := apiClientResourceObject(apic, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -163,8 +154,7 @@ func (o *apiclientsOptions) handleSingleAPIClient(cmd *cobra.Command, _ []string
 
 func (o *apiclientsOptions) handleAPIClientList(cmd *cobra.Command, _ []string) error {
 
-	c := security.NewAPIClient()
-	apiclis, uri, err := c.GetAPIClients(cmd.Context(), o.search, o.sort, o.page, o.limit)
+	apiclis, resourceObj, err := o.listAPIClients(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -174,6 +164,66 @@ func (o *apiclientsOptions) handleAPIClientList(cmd *cobra.Command, _ []string)
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *apiclientsOptions) getAPIClient(ctx context.Context) (*security.APIClientConfig, string, error) {
+	c := security.NewAPIClient()
+	if o.id != "" {
+		return c.GetAPIClientByID(ctx, o.id)
+	}
+
+	return c.GetAPIClientByName(ctx, o.name)
+}
+
+func (o *apiclientsOptions) fetchAPIClients(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		if single {
+			apic, uri, err := o.getAPIClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{apiClientResourceObject(apic, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listAPIClients(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func apiClientResourceObject(apic *security.APIClientConfig, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "APIClient",
+		APIVersion: "1.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			UID:  *apic.ClientID,
+			Name: apic.ClientName,
+			URI:  uri,
+		},
+		Data: apic,
+	}
+}
+
+// listAPIClients gets the API clients and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *apiclientsOptions) listAPIClients(ctx context.Context) (*security.APIClientListResponse, *resource.ResourceObjectList, error) {
+	c := security.NewAPIClient()
+	apiclis, uri, err := c.GetAPIClients(ctx, o.search, o.sort, o.page, o.limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, apic := range *apiclis.APIClients {
 		items = append(items, &resource.ResourceObject{
@@ -187,7 +237,7 @@ func (o *apiclientsOptions) handleAPIClientList(cmd *cobra.Command, _ []string)
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return apiclis, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "1.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -195,13 +245,5 @@ func (o *apiclientsOptions) handleAPIClientList(cmd *cobra.Command, _ []string)
 			Total: int(*apiclis.Total),
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
@@ -1,11 +1,13 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/directory"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	attributesUsage         = `attributes [flags]`
 	attributesMessagePrefix = "GetAttributes"
-	attributesEntitlements  = "Manage attributes"
 	attributeResourceName   = "attribute"
 )
 
@@ -90,6 +91,10 @@ func (o *attributesOptions) Validate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "attribute" && o.id == "" {
 		return errorsx.G11NError("'id' flag is required.")
@@ -99,17 +104,22 @@ func (o *attributesOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *attributesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+attributesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceAttributes))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "attribute" || len(o.id) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceAttributes, "1.0", o.fetchAttributes(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceAttributes)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "attribute" || len(o.id) > 0 {
+	if single {
 		// deal with single attribute
 		return o.handleSingleAttribute(cmd, args)
 	}
@@ -130,17 +140,7 @@ func (o *attributesOptions) handleSingleAttribute(cmd *cobra.Command, _ []string
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "Attribute",
-		APIVersion: "1.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			UID:  *attr.ID,
-			Name: attr.Name,
-			URI:  uri,
-		},
-		Data: attr,
-	}
-
+	resourceObj := attributeResourceObject(attr, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -152,8 +152,7 @@ func (o *attributesOptions) handleSingleAttribute(cmd *cobra.Command, _ []string
 
 func (o *attributesOptions) handleAttributeList(cmd *cobra.Command, _ []string) error {
 
-	c := directory.NewAttributeClient()
-	attrs, uri, err := c.GetAttributes(cmd.Context(), o.search, o.sort, o.page, o.limit)
+	attrs, resourceObj, err := o.listAttributes(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -163,6 +162,58 @@ func (o *attributesOptions) handleAttributeList(cmd *cobra.Command, _ []string)
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *attributesOptions) fetchAttributes(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		c := directory.NewAttributeClient()
+		if single {
+			attr, uri, err := c.GetAttribute(ctx, o.id)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{attributeResourceObject(attr, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listAttributes(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func attributeResourceObject(attr *directory.Attribute, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "Attribute",
+		APIVersion: "1.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			UID:  *attr.ID,
+			Name: attr.Name,
+			URI:  uri,
+		},
+		Data: attr,
+	}
+}
+
+// listAttributes gets the attributes and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *attributesOptions) listAttributes(ctx context.Context) (*directory.AttributeList, *resource.ResourceObjectList, error) {
+	c := directory.NewAttributeClient()
+	attrs, uri, err := c.GetAttributes(ctx, o.search, o.sort, o.page, o.limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, attr := range attrs.Attributes {
 		items = append(items, &resource.ResourceObject{
@@ -176,7 +227,7 @@ func (o *attributesOptions) handleAttributeList(cmd *cobra.Command, _ []string)
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return attrs, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "1.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -187,13 +238,5 @@ func (o *attributesOptions) handleAttributeList(cmd *cobra.Command, _ []string)
 			Page:  attrs.Page,
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
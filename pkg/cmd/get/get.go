@@ -29,14 +29,21 @@ You can identify the entitlement required by running:
   
 The flags supported by each resource type may differ and can be determined using:
 
-  verifyctl get [resource-type] -h`))
+  verifyctl get [resource-type] -h
+
+Resources can be fetched from several tenants at once using "--tenants" or "--all-tenants", which use the
+saved sessions. The results are combined into a single list and each item carries its tenant. A tenant
+that fails is reported without stopping the others, and the command exits with a non-zero code.`))
 
 	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
 		# Get an application
 		verifyctl get application -o=yaml --id=1098012
 
 		# Get all users that match department "2A". There may be limits introduced by the API.
-		verifyctl get users --filter="urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq \"2A\"" --attributes="userName,emails,urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager" -o yaml`))
+		verifyctl get users --filter="urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department eq \"2A\"" --attributes="userName,emails,urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager" -o yaml
+
+		# Get the API clients of two tenants
+		verifyctl get apiclients --tenants=abc.verify.ibm.com,xyz.verify.ibm.com -o=yaml`))
 
 	entitlementsMessage = i18n.Translate("Choose any of the following entitlements to configure your application or API client:\n")
 )
@@ -51,8 +58,10 @@ type options struct {
 	search       string
 	count        string
 	//properties   string
-	id   string
-	name string
+	id         string
+	name       string
+	tenants    []string
+	allTenants bool
 
 	config *config.CLIConfig
 }
@@ -94,6 +103,7 @@ func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string)
 func (o *options) addCommonFlags(cmd *cobra.Command, resourceName string) {
 	cmd.Flags().BoolVar(&o.entitlements, "entitlements", o.entitlements, i18n.TranslateWithArgs("List the entitlements that can be configured to grant access to the %s. This is useful to know what to configure on the application or API client used to generate the login token. When this flag is used, the others are ignored.", resourceName))
 	cmd.Flags().StringVarP(&o.output, "output", "o", "", i18n.Translate("Select the format of the output. The values supported are 'json' , 'yaml' and 'raw'. Default: 'json'."))
+	o.addTenantsFlags(cmd, resourceName)
 }
 
 func (o *options) addIdFlag(cmd *cobra.Command, resourceName string) {
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/directory"
@@ -8,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	groupsUsage         = `groups [flags]`
 	groupsMessagePrefix = "GetGroups"
-	groupsEntitlements  = "Manage groups"
 	groupResourceName   = "group"
 )
 
@@ -89,6 +90,10 @@ func (o *groupsOptions) Validate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "group" && o.name == "" {
 		return errorsx.G11NError("'displayName' flag is required.")
@@ -98,17 +103,22 @@ func (o *groupsOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *groupsOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+groupsEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceGroups))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "group" || len(o.name) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceGroups, "2.0", o.fetchGroups(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceGroups)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "group" || len(o.name) > 0 {
+	if single {
 		// deal with single group
 		return o.handleSingleGroup(cmd, args)
 	}
@@ -129,16 +139,7 @@ func (o *groupsOptions) handleSingleGroup(cmd *cobra.Command, _ []string) error
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "Group",
-		APIVersion: "2.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			Name: grp.DisplayName,
-			URI:  uri,
-		},
-		Data: grp,
-	}
-
+	resourceObj := groupResourceObject(grp, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -150,8 +151,7 @@ func (o *groupsOptions) handleSingleGroup(cmd *cobra.Command, _ []string) error
 
 func (o *groupsOptions) handleGroupList(cmd *cobra.Command, _ []string) error {
 
-	c := directory.NewGroupClient()
-	grps, uri, err := c.GetGroups(cmd.Context(), o.sort, o.count)
+	grps, resourceObj, err := o.listGroups(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -161,6 +161,57 @@ func (o *groupsOptions) handleGroupList(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *groupsOptions) fetchGroups(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		c := directory.NewGroupClient()
+		if single {
+			grp, uri, err := c.GetGroupByName(ctx, o.name)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{groupResourceObject(grp, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listGroups(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func groupResourceObject(grp *directory.Group, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "Group",
+		APIVersion: "2.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			Name: grp.DisplayName,
+			URI:  uri,
+		},
+		Data: grp,
+	}
+}
+
+// listGroups gets the groups and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *groupsOptions) listGroups(ctx context.Context) (*directory.GroupListResponse, *resource.ResourceObjectList, error) {
+	c := directory.NewGroupClient()
+	grps, uri, err := c.GetGroups(ctx, o.sort, o.count)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, grp := range *grps.Resources {
 		items = append(items, &resource.ResourceObject{
@@ -173,7 +224,7 @@ func (o *groupsOptions) handleGroupList(cmd *cobra.Command, _ []string) error {
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return grps, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "2.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -181,13 +232,5 @@ func (o *groupsOptions) handleGroupList(cmd *cobra.Command, _ []string) error {
 			Total: int(grps.TotalResults),
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
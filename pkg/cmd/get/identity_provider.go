@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
@@ -8,6 +9,7 @@ import (
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
 	"github.com/ibm-verify/verifyctl/pkg/module/directory"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	identitysourcesUsage         = `identitysources [flags]`
 	identitysourcesMessagePrefix = "GetIdentitysources"
-	identitysourcesEntitlements  = "Manage identitysources"
 	identitysourceResourceName   = "identitysource"
 )
 
@@ -89,6 +90,10 @@ func (o *identitysourcesOptions) Validate(cmd *cobra.Command, args []string) err
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "identitysource" && o.name == "" {
 		return errorsx.G11NError("'displayName' flag is required.")
@@ -98,17 +103,22 @@ func (o *identitysourcesOptions) Validate(cmd *cobra.Command, args []string) err
 
 func (o *identitysourcesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+identitysourcesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceIdentitySources))
 		return nil
 	}
 
-	auth, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "identitysource" || len(o.name) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceIdentitySources, "2.0", o.fetchIdentitySources(single))
+	}
+
+	auth, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceIdentitySources)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "identitysource" || len(o.name) > 0 {
+	if single {
 		// deal with single identitysource
 		return o.handleSingleIdentitysource(cmd, auth, args)
 	}
@@ -129,16 +139,7 @@ func (o *identitysourcesOptions) handleSingleIdentitysource(cmd *cobra.Command,
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "IdentitySource",
-		APIVersion: "2.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			Name: is.InstanceName,
-			URI:  uri,
-		},
-		Data: is,
-	}
-
+	resourceObj := identitySourceResourceObject(is, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -150,8 +151,7 @@ func (o *identitysourcesOptions) handleSingleIdentitysource(cmd *cobra.Command,
 
 func (o *identitysourcesOptions) handleIdentitysourceList(cmd *cobra.Command, auth *config.AuthConfig, _ []string) error {
 
-	c := directory.NewIdentitySourceClient()
-	iss, uri, err := c.GetIdentitysources(cmd.Context(), auth, o.sort, o.count)
+	iss, resourceObj, err := o.listIdentitySources(cmd.Context(), auth)
 	if err != nil {
 		return err
 	}
@@ -161,6 +161,57 @@ func (o *identitysourcesOptions) handleIdentitysourceList(cmd *cobra.Command, au
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *identitysourcesOptions) fetchIdentitySources(single bool) tenantFetcher {
+	return func(ctx context.Context, auth *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		c := directory.NewIdentitySourceClient()
+		if single {
+			is, uri, err := c.GetIdentitysource(ctx, auth, o.name)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{identitySourceResourceObject(is, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listIdentitySources(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func identitySourceResourceObject(is *directory.IdentitySource, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "IdentitySource",
+		APIVersion: "2.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			Name: is.InstanceName,
+			URI:  uri,
+		},
+		Data: is,
+	}
+}
+
+// listIdentitySources gets the identity sources and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *identitysourcesOptions) listIdentitySources(ctx context.Context, auth *config.AuthConfig) (*directory.IdentitySourceList, *resource.ResourceObjectList, error) {
+	c := directory.NewIdentitySourceClient()
+	iss, uri, err := c.GetIdentitysources(ctx, auth, o.sort, o.count)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, is := range iss.IdentitySources {
 		items = append(items, &resource.ResourceObject{
@@ -173,7 +224,7 @@ func (o *identitysourcesOptions) handleIdentitysourceList(cmd *cobra.Command, au
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return iss, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "2.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -181,13 +232,5 @@ func (o *identitysourcesOptions) handleIdentitysourceList(cmd *cobra.Command, au
 			Total: int(iss.Total),
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
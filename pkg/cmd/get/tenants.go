@@ -0,0 +1,169 @@
+package get
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// maxConcurrentTenants limits the number of tenants that are called at the same time.
+const maxConcurrentTenants = 8
+
+// tenantFetcher returns the resources of the tenant whose session is set to the context.
+type tenantFetcher func(ctx context.Context, auth *config.AuthConfig) ([]*resource.ResourceObject, error)
+
+type tenantResult struct {
+	items []*resource.ResourceObject
+	err   error
+}
+
+// listItems returns the resource objects of a list built by a get command.
+func listItems(list *resource.ResourceObjectList) []*resource.ResourceObject {
+	items, _ := list.Items.([]*resource.ResourceObject)
+	return items
+}
+
+func (o *options) addTenantsFlags(cmd *cobra.Command, resourceName string) {
+	cmd.Flags().StringSliceVar(&o.tenants, "tenants", nil, i18n.TranslateWithArgs("Comma-separated list of tenants to get the %s from. Each tenant must have a saved session. The results are combined into a single list.", resourceName))
+	cmd.Flags().BoolVar(&o.allTenants, "all-tenants", false, i18n.TranslateWithArgs("Get the %s from every tenant that has a saved session. The results are combined into a single list.", resourceName))
+}
+
+func (o *options) multiTenant() bool {
+	return o.allTenants || len(o.tenants) > 0
+}
+
+func (o *options) validateTenants(cliConfig *config.CLIConfig) error {
+	if !o.multiTenant() {
+		return nil
+	}
+
+	if o.allTenants && len(o.tenants) > 0 {
+		return errorsx.G11NError("'tenants' and 'all-tenants' cannot be used together.")
+	}
+
+	if o.output == "raw" {
+		return errorsx.G11NError("The 'raw' output is not supported with 'tenants' or 'all-tenants'.")
+	}
+
+	if auth, _ := cliConfig.GetCurrentAuth(); auth != nil && auth.IsEphemeral() {
+		return errorsx.G11NError("'tenants' and 'all-tenants' use the saved sessions and cannot be used with credentials provided using flags or environment variables.")
+	}
+
+	return nil
+}
+
+// runForTenants fetches the resources from each tenant concurrently and writes them as
+// a single list, with the tenant set on each item. A tenant that fails is reported and
+// does not stop the others. An error is returned after the list is written if any
+// tenant failed.
+func (o *options) runForTenants(cmd *cobra.Command, cliConfig *config.CLIConfig, verb string, resourceName string, apiVersion string, fetch tenantFetcher) error {
+	ctx := cmd.Context()
+	vc := contextx.GetVerifyContext(ctx)
+
+	tenants := o.tenants
+	if o.allTenants {
+		tenants = cliConfig.Tenants()
+	}
+
+	if len(tenants) == 0 {
+		return errorsx.G11NError("No login session available. Use:\n  verifyctl login -h")
+	}
+
+	results := make([]tenantResult, len(tenants))
+	sem := make(chan struct{}, maxConcurrentTenants)
+	var wg sync.WaitGroup
+	for i, tenant := range tenants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = fetchFromTenant(ctx, cliConfig, tenant, verb, resourceName, fetch)
+			if results[i].err != nil {
+				vc.Logger.Errorf("unable to get the resources; tenant=%s, resource=%s, err=%v", tenant, resourceName, results[i].err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	items := []*resource.ResourceObject{}
+	failed := 0
+	for i, result := range results {
+		if result.err != nil {
+			failed++
+			_, _ = io.WriteString(cmd.ErrOrStderr(), i18n.TranslateWithArgs("Error from the tenant '%s': %v", tenants[i], result.err)+"\n")
+			continue
+		}
+
+		items = append(items, result.items...)
+	}
+
+	resourceObj := &resource.ResourceObjectList{
+		Kind:       resource.ResourceTypePrefix + "List",
+		APIVersion: apiVersion,
+		Metadata: &resource.ResourceObjectMetadata{
+			Total: len(items),
+		},
+		Items: items,
+	}
+
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+		cmdutil.WriteString(cmd, "")
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	if failed > 0 {
+		return errorsx.G11NError("The %s could not be fetched from %d of %d tenants.", resourceName, failed, len(tenants))
+	}
+
+	return nil
+}
+
+// fetchFromTenant fetches the resources using a session of the tenant. Each tenant has
+// its own verify context, as the SDK clients read the tenant and the token from it.
+func fetchFromTenant(ctx context.Context, cliConfig *config.CLIConfig, tenant string, verb string, resourceName string, fetch tenantFetcher) tenantResult {
+	if !slices.Contains(cliConfig.Tenants(), tenant) {
+		return tenantResult{err: errorsx.G11NError("No login session available for the tenant.")}
+	}
+
+	vc := contextx.GetVerifyContext(ctx)
+	tenantCtx, err := contextx.NewContextWithVerifyContext(ctx, vc.Logger)
+	if err != nil {
+		return tenantResult{err: err}
+	}
+
+	auth, err := entitlements.SetAuthToContext(tenantCtx, cliConfig.ForTenant(tenant), verb, resourceName)
+	if err != nil {
+		return tenantResult{err: err}
+	}
+
+	items, err := fetch(tenantCtx, auth)
+	if err != nil {
+		return tenantResult{err: err}
+	}
+
+	for _, item := range items {
+		if item.Metadata == nil {
+			item.Metadata = &resource.ResourceObjectMetadata{}
+		}
+
+		item.Metadata.Tenant = tenant
+	}
+
+	return tenantResult{items: items}
+}
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"encoding/base64"
 	"io"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -17,7 +19,6 @@ import (
 const (
 	themesUsage         = `themes [flags]`
 	themesMessagePrefix = "GetThemes"
-	themesEntitlements  = "manageTemplates (Manage templates and themes) or readTemplates (Read templates and themes)"
 	themeResourceName   = "theme"
 )
 
@@ -101,6 +102,10 @@ func (o *themesOptions) Validate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "theme" {
 		if o.id == "" {
@@ -111,28 +116,36 @@ func (o *themesOptions) Validate(cmd *cobra.Command, args []string) error {
 			return errorsx.G11NError("'dir' flag is required when 'unpack' flag is used.")
 		}
 	}
+
+	if o.unpack && o.multiTenant() {
+		return errorsx.G11NError("'unpack' cannot be used with 'tenants' or 'all-tenants'.")
+	}
 	return nil
 }
 
 func (o *themesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+themesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceThemes))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "theme" || len(o.id) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceThemes, "1.0", o.fetchThemes(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceThemes)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "theme" || len(o.id) > 0 {
+	if single {
 		return o.handleSingleThemeCommand(cmd, args)
 	}
 
 	// deal with themes
-	c := branding.NewThemeClient()
-	themes, uri, err := c.ListThemes(cmd.Context(), 0, o.page, o.limit)
+	themes, resourceObj, err := o.listThemes(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -142,85 +155,121 @@ func (o *themesOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	items := []*resource.ResourceObject{}
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
 
-	for _, theme := range themes.Themes {
-		items = append(items, &resource.ResourceObject{
-			Kind:       resource.ResourceTypePrefix + "Theme",
-			APIVersion: "1.0",
-			Metadata: &resource.ResourceObjectMetadata{
-				UID:  theme.ThemeID,
-				Name: theme.Name,
-			},
-			Data: theme,
-		})
+	return nil
+}
+
+func (o *themesOptions) handleSingleThemeCommand(cmd *cobra.Command, _ []string) error {
+	b, uri, err := o.getTheme(cmd.Context())
+	if err != nil {
+		return err
 	}
 
-	resourceObj := &resource.ResourceObjectList{
-		Kind:       resource.ResourceTypePrefix + "List",
-		APIVersion: "1.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			URI:   uri,
-			Limit: themes.Limit,
-			Count: themes.Count,
-			Total: themes.Total,
-			Page:  themes.Page,
-		},
-		Items: items,
+	if len(o.path) == 0 && o.unpack {
+		return cmdutil.UnpackZipToDirectory(cmd, b, o.outputDirectory)
 	}
 
+	if o.output == "raw" {
+		cmdutil.WriteAsBinary(cmd, b, cmd.OutOrStdout())
+		return nil
+	}
+
+	obj := o.themeResourceObject(b, uri)
 	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+		cmdutil.WriteAsJSON(cmd, obj, cmd.OutOrStdout())
 	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+		cmdutil.WriteAsYAML(cmd, obj, cmd.OutOrStdout())
 	}
 
 	return nil
 }
 
-func (o *themesOptions) handleSingleThemeCommand(cmd *cobra.Command, _ []string) error {
+// getTheme returns the theme, or a single file of the theme if a path is set.
+func (o *themesOptions) getTheme(ctx context.Context) ([]byte, string, error) {
 	c := branding.NewThemeClient()
-	var b []byte
-	var err error
-	uri := ""
-	uid := o.id
-	resourceName := "Theme"
 	if len(o.path) > 0 {
 		// get a single file
-		if b, uri, err = c.GetFile(cmd.Context(), o.id, o.path); err != nil {
-			return err
+		return c.GetFile(ctx, o.id, o.path)
+	}
+
+	return c.GetTheme(ctx, o.id, o.customizedOnly)
+}
+
+func (o *themesOptions) fetchThemes(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		if single {
+			b, uri, err := o.getTheme(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{o.themeResourceObject(b, uri)}, nil
 		}
-		resourceName = "ThemeFile"
-	} else {
-		if b, uri, err = c.GetTheme(cmd.Context(), o.id, o.customizedOnly); err != nil {
-			return err
+
+		_, resourceObj, err := o.listThemes(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if len(o.path) == 0 && o.unpack {
-		return cmdutil.UnpackZipToDirectory(cmd, b, o.outputDirectory)
+		return listItems(resourceObj), nil
 	}
+}
 
-	if o.output == "raw" {
-		cmdutil.WriteAsBinary(cmd, b, cmd.OutOrStdout())
-		return nil
+func (o *themesOptions) themeResourceObject(b []byte, uri string) *resource.ResourceObject {
+	resourceName := "Theme"
+	if len(o.path) > 0 {
+		resourceName = "ThemeFile"
 	}
 
-	obj := &resource.ResourceObject{
+	return &resource.ResourceObject{
 		Kind:       string(resource.ResourceTypePrefix) + resourceName,
 		APIVersion: "1.0",
 		Metadata: &resource.ResourceObjectMetadata{
-			UID: uid,
+			UID: o.id,
 			URI: uri,
 		},
 		Data: base64.StdEncoding.EncodeToString(b),
 	}
+}
 
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, obj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, obj, cmd.OutOrStdout())
+// listThemes gets the themes and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *themesOptions) listThemes(ctx context.Context) (*branding.ListThemesResponse, *resource.ResourceObjectList, error) {
+	c := branding.NewThemeClient()
+	themes, uri, err := c.ListThemes(ctx, 0, o.page, o.limit)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	items := []*resource.ResourceObject{}
+
+	for _, theme := range themes.Themes {
+		items = append(items, &resource.ResourceObject{
+			Kind:       resource.ResourceTypePrefix + "Theme",
+			APIVersion: "1.0",
+			Metadata: &resource.ResourceObjectMetadata{
+				UID:  theme.ThemeID,
+				Name: theme.Name,
+			},
+			Data: theme,
+		})
+	}
+
+	return themes, &resource.ResourceObjectList{
+		Kind:       resource.ResourceTypePrefix + "List",
+		APIVersion: "1.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			URI:   uri,
+			Limit: themes.Limit,
+			Count: themes.Count,
+			Total: themes.Total,
+			Page:  themes.Page,
+		},
+		Items: items,
+	}, nil
 }
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"io"
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/config/directory"
@@ -8,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -16,7 +18,6 @@ import (
 const (
 	usersUsage         = `users [flags]`
 	usersMessagePrefix = "GetUsers"
-	usersEntitlements  = "Manage users"
 	userResourceName   = "user"
 )
 
@@ -89,6 +90,10 @@ func (o *usersOptions) Validate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if err := o.validateTenants(o.config); err != nil {
+		return err
+	}
+
 	calledAs := cmd.CalledAs()
 	if calledAs == "user" && o.name == "" {
 		return errorsx.G11NError("'userName' flag is required.")
@@ -98,17 +103,22 @@ func (o *usersOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *usersOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+usersEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceUsers))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	single := cmd.CalledAs() == "user" || len(o.name) > 0
+	if o.multiTenant() {
+		return o.runForTenants(cmd, o.config, entitlements.VerbGet, entitlements.ResourceUsers, "2.0", o.fetchUsers(single))
+	}
+
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceUsers)
 	if err != nil {
 		return err
 	}
 
 	// invoke the operation
-	if cmd.CalledAs() == "user" || len(o.name) > 0 {
+	if single {
 		// deal with single user
 		return o.handleSingleUser(cmd, args)
 	}
@@ -129,17 +139,7 @@ func (o *usersOptions) handleSingleUser(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	resourceObj := &resource.ResourceObject{
-		Kind:       resource.ResourceTypePrefix + "User",
-		APIVersion: "2.0",
-		Metadata: &resource.ResourceObjectMetadata{
-			UID:  usr.ID,
-			Name: usr.UserName,
-			URI:  uri,
-		},
-		Data: usr,
-	}
-
+	resourceObj := userResourceObject(usr, uri)
 	if o.output == "json" {
 		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
 	} else {
@@ -151,8 +151,7 @@ func (o *usersOptions) handleSingleUser(cmd *cobra.Command, _ []string) error {
 
 func (o *usersOptions) handleUserList(cmd *cobra.Command, _ []string) error {
 
-	c := directory.NewUserClient()
-	usrs, uri, err := c.GetUsers(cmd.Context(), o.sort, o.count)
+	usrs, resourceObj, err := o.listUsers(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -162,6 +161,58 @@ func (o *usersOptions) handleUserList(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if o.output == "json" {
+		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
+	} else {
+		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func (o *usersOptions) fetchUsers(single bool) tenantFetcher {
+	return func(ctx context.Context, _ *config.AuthConfig) ([]*resource.ResourceObject, error) {
+		c := directory.NewUserClient()
+		if single {
+			usr, uri, err := c.GetUser(ctx, o.name)
+			if err != nil {
+				return nil, err
+			}
+
+			return []*resource.ResourceObject{userResourceObject(usr, uri)}, nil
+		}
+
+		_, resourceObj, err := o.listUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return listItems(resourceObj), nil
+	}
+}
+
+func userResourceObject(usr *directory.User, uri string) *resource.ResourceObject {
+	return &resource.ResourceObject{
+		Kind:       resource.ResourceTypePrefix + "User",
+		APIVersion: "2.0",
+		Metadata: &resource.ResourceObjectMetadata{
+			UID:  usr.ID,
+			Name: usr.UserName,
+			URI:  uri,
+		},
+		Data: usr,
+	}
+}
+
+// listUsers gets the users and returns them along with the list that is written, so
+// that the current tenant and the tenants flags build the same list.
+func (o *usersOptions) listUsers(ctx context.Context) (*directory.UserListResponse, *resource.ResourceObjectList, error) {
+	c := directory.NewUserClient()
+	usrs, uri, err := c.GetUsers(ctx, o.sort, o.count)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	items := []*resource.ResourceObject{}
 	for _, usr := range *usrs.Resources {
 		items = append(items, &resource.ResourceObject{
@@ -175,7 +226,7 @@ func (o *usersOptions) handleUserList(cmd *cobra.Command, _ []string) error {
 		})
 	}
 
-	resourceObj := &resource.ResourceObjectList{
+	return usrs, &resource.ResourceObjectList{
 		Kind:       resource.ResourceTypePrefix + "List",
 		APIVersion: "2.0",
 		Metadata: &resource.ResourceObjectMetadata{
@@ -183,13 +234,5 @@ func (o *usersOptions) handleUserList(cmd *cobra.Command, _ []string) error {
 			Total: int(usrs.TotalResults),
 		},
 		Items: items,
-	}
-
-	if o.output == "json" {
-		cmdutil.WriteAsJSON(cmd, resourceObj, cmd.OutOrStdout())
-	} else {
-		cmdutil.WriteAsYAML(cmd, resourceObj, cmd.OutOrStdout())
-	}
-
-	return nil
+	}, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	"github.com/ibm-verify/verifyctl/pkg/module/logs"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
@@ -14,7 +15,6 @@ import (
 const (
 	usage         = "logs [flags]"
 	messagePrefix = "Logs"
-	entitlements  = "Read trace logs (readTraceLogs)"
 )
 
 var (
@@ -92,11 +92,11 @@ func (o *options) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbGet, entitlements.ResourceLogs))
 		return nil
 	}
 
-	auth, err := o.config.SetAuthToContext(cmd.Context())
+	auth, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbGet, entitlements.ResourceLogs)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/proxy"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/ibm-verify/verifyctl/pkg/util/templates"
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	usage         = "proxy [flags]"
+	messagePrefix = "Proxy"
+
+	defaultPort = 8001
+)
+
+var (
+	longDesc = templates.LongDesc(cmdutil.TranslateLongDesc(messagePrefix, `
+		Run a local reverse proxy to your tenant.
+
+Requests sent to the proxy, such as "http://localhost:8001/v2.0/Users", are forwarded to the tenant
+of the current context with the access token of the session. Tools like Postman and local scripts
+can call the tenant APIs without handling tokens. The token is renewed when it expires or when the
+tenant rejects it. The credential used can be selected with the global "--credential" flag.
+
+The proxy listens on the loopback address by default. Anyone who can reach it acts with the
+entitlements of the session, so restrict the requests that are allowed with "--allow-prefix" and
+"--allow-method". Requests with a Host header other than a loopback name or address are rejected,
+unless allowed with "--allow-host". Browser pages can use the proxy only from the origins allowed
+with "--allow-origin".
+
+Each request is logged to the trace log in the ".verify" directory. Set LOG_LEVEL=warn to log only
+the requests that are rejected. Press Ctrl-C to stop the proxy.`))
+
+	examples = templates.Examples(cmdutil.TranslateExamples(messagePrefix, `
+		# Run the proxy on port 8001 and list the users
+		verifyctl proxy &
+		curl http://localhost:8001/v2.0/Users
+
+		# Allow only reading users and groups
+		verifyctl proxy --allow-prefix=/v2.0/Users --allow-prefix=/v2.0/Groups --allow-method=GET
+
+		# Allow a browser-based tool served from http://localhost:3000
+		verifyctl proxy --port=9000 --allow-origin=http://localhost:3000`))
+)
+
+type options struct {
+	address       string
+	port          int
+	allowPrefixes []string
+	allowMethods  []string
+	allowHosts    []string
+	allowOrigins  []string
+
+	config *config.CLIConfig
+}
+
+func NewCommand(config *config.CLIConfig, streams io.ReadWriter, groupID string) *cobra.Command {
+	o := &options{
+		config: config,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   usage,
+		Short:                 cmdutil.TranslateShortDesc(messagePrefix, "Run a local reverse proxy to your tenant."),
+		Long:                  longDesc,
+		Example:               examples,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.ExitOnError(cmd, o.Complete(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Validate(cmd, args))
+			cmdutil.ExitOnError(cmd, o.Run(cmd, args))
+		},
+		GroupID: groupID,
+	}
+
+	cmd.SetOut(streams)
+	cmd.SetErr(streams)
+	cmd.SetIn(streams)
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.address, "address", "127.0.0.1", i18n.Translate("Address to listen on."))
+	cmd.Flags().IntVarP(&o.port, "port", "p", defaultPort, i18n.Translate("Port to listen on. Use 0 to pick a free port."))
+	cmd.Flags().StringArrayVar(&o.allowPrefixes, "allow-prefix", nil, i18n.Translate("Path prefix that can be requested, such as '/v2.0/Users'. Can be repeated. By default, all paths are allowed."))
+	cmd.Flags().StringArrayVar(&o.allowMethods, "allow-method", nil, i18n.Translate("HTTP method that can be used, such as 'GET'. Can be repeated. By default, all methods are allowed."))
+	cmd.Flags().StringArrayVar(&o.allowHosts, "allow-host", nil, i18n.Translate("Host name accepted in the Host header, in addition to the loopback names and addresses. Can be repeated."))
+	cmd.Flags().StringArrayVar(&o.allowOrigins, "allow-origin", nil, i18n.Translate("Origin of browser pages that can use the proxy, such as 'http://localhost:3000'. Can be repeated."))
+}
+
+func (o *options) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+func (o *options) Validate(cmd *cobra.Command, args []string) error {
+	if o.port < 0 || o.port > 65535 {
+		return errorsx.G11NError("'port' must be between 0 and 65535.")
+	}
+
+	return nil
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	vc := contextx.GetVerifyContext(ctx)
+	auth, err := o.config.SetAuthToContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(o.address, strconv.Itoa(o.port)))
+	if err != nil {
+		vc.Logger.Errorf("unable to start the proxy; err=%v", err)
+		return errorsx.G11NError("unable to listen on '%s'; err=%v", net.JoinHostPort(o.address, strconv.Itoa(o.port)), err)
+	}
+
+	if ip := net.ParseIP(o.address); (ip == nil && o.address != "localhost") || (ip != nil && !ip.IsLoopback()) {
+		cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Warning: the proxy listens on '%s' and can be reached from other machines.", o.address))
+	}
+
+	server := &http.Server{
+		Handler: proxy.NewProxy(ctx, o.config, auth, &proxy.Options{
+			AllowedPrefixes: o.allowPrefixes,
+			AllowedMethods:  o.allowMethods,
+			AllowedHosts:    o.allowHosts,
+			AllowedOrigins:  o.allowOrigins,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Proxying http://%s to %s", listener.Addr().String(), auth.ServerURL()))
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		vc.Logger.Errorf("the proxy stopped; err=%v", err)
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -19,7 +20,6 @@ import (
 const (
 	accessPolicyUsage         = `accesspolicy [options]`
 	accessPolicyMessagePrefix = "UpdateAccessPolicy"
-	accessPolicyEntitlements  = "Manage accessPolicies"
 	accessPolicyResourceName  = "accesspolicy"
 )
 
@@ -103,7 +103,7 @@ func (o *accessPolicyOptions) Validate(cmd *cobra.Command, args []string) error
 
 func (o *accessPolicyOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+accessPolicyEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbReplace, entitlements.ResourceAccessPolicies))
 		return nil
 	}
 
@@ -120,7 +120,7 @@ func (o *accessPolicyOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbReplace, entitlements.ResourceAccessPolicies)
 	if err != nil {
 		return err
 	}
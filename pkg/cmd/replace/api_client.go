@@ -9,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -21,7 +22,6 @@ import (
 const (
 	apiclientUsage         = `apiclient [options]`
 	apiclientMessagePrefix = "UpdateApiclient"
-	apiclientEntitlements  = "manageAPIClients"
 	apiclientResourceName  = "apiclient"
 )
 
@@ -99,7 +99,7 @@ func (o *apiclientOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *apiclientOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+apiclientEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbReplace, entitlements.ResourceAPIClients))
 		return nil
 	}
 
@@ -118,7 +118,7 @@ func (o *apiclientOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbReplace, entitlements.ResourceAPIClients)
 	if err != nil {
 		return err
 	}
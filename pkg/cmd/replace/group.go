@@ -9,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -20,7 +21,6 @@ import (
 const (
 	groupUsage         = `group [options]`
 	groupMessagePrefix = "UpdateGroup"
-	groupEntitlements  = "Manage groups"
 	groupResourceName  = "group"
 )
 
@@ -104,7 +104,7 @@ func (o *groupOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *groupOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+groupEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbReplace, entitlements.ResourceGroups))
 		return nil
 	}
 	id := "<id>"
@@ -122,7 +122,7 @@ func (o *groupOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbReplace, entitlements.ResourceGroups)
 	if err != nil {
 		return err
 	}
@@ -183,6 +183,10 @@ func (o *groupOptions) updateGroupFromDataMap(cmd *cobra.Command, data map[strin
 		return err
 	}
 
+	if group.SCIMPatchRequest == nil {
+		return errorsx.G11NError("The 'scimPatch' field is required to update a group.")
+	}
+
 	client := directory.NewGroupClient()
 	if err := client.UpdateGroup(ctx, group.GroupName, &group.SCIMPatchRequest.Operations); err != nil {
 		vc.Logger.Errorf("unable to update the group; err=%v, group=%+v", err, group)
@@ -9,6 +9,7 @@ import (
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
 	"github.com/ibm-verify/verifyctl/pkg/module/directory"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -20,7 +21,6 @@ import (
 const (
 	identitysourceUsage         = `identitysource [options]`
 	identitysourceMessagePrefix = "UpdateIdentitysource"
-	identitysourceEntitlements  = "Manage identitysources"
 	identitysourceResourceName  = "identitysource"
 )
 
@@ -104,7 +104,7 @@ func (o *identitysourceOptions) Validate(cmd *cobra.Command, args []string) erro
 
 func (o *identitysourceOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+identitysourceEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbReplace, entitlements.ResourceIdentitySources))
 		return nil
 	}
 
@@ -121,7 +121,7 @@ func (o *identitysourceOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	auth, err := o.config.SetAuthToContext(cmd.Context())
+	auth, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbReplace, entitlements.ResourceIdentitySources)
 	if err != nil {
 		return err
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/cmd/resource"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -20,7 +21,6 @@ import (
 const (
 	userUsage         = `user [options]`
 	userMessagePrefix = "UpdateUser"
-	userEntitlements  = "Manage users"
 	userResourceName  = "user"
 )
 
@@ -104,7 +104,7 @@ func (o *userOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *userOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+userEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbReplace, entitlements.ResourceUsers))
 		return nil
 	}
 
@@ -122,7 +122,7 @@ func (o *userOptions) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbReplace, entitlements.ResourceUsers)
 	if err != nil {
 		return err
 	}
@@ -183,6 +183,10 @@ func (o *userOptions) updateUserFromDataMap(cmd *cobra.Command, data map[string]
 		return err
 	}
 
+	if user.SCIMPatchRequest == nil {
+		return errorsx.G11NError("The 'scimPatch' field is required to update a user.")
+	}
+
 	client := directory.NewUserClient()
 	if err := client.UpdateUser(ctx, user.UserName, &user.SCIMPatchRequest.Operations); err != nil {
 		vc.Logger.Errorf("unable to update the user; err=%v, user=%+v", err, user)
@@ -0,0 +1,337 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// IgnoreFileName marks a directory whose files are not resources, such as the themes
+// written by 'export'.
+const IgnoreFileName = ".verifyignore"
+
+// SourcedResourceObject is a resource object along with the file it was read from.
+type SourcedResourceObject struct {
+	*ResourceObject
+
+	// Source is the file name, followed by the position of the resource if the file
+	// holds more than one, such as "users.yaml#2".
+	Source string
+}
+
+// ResourceResult is the outcome of an operation on a resource.
+type ResourceResult struct {
+	Object *SourcedResourceObject
+	Err    error
+}
+
+// LoadResourceObjects reads the resource objects from a file, a directory or stdin if
+// the path is "-". Files may hold several YAML documents separated by "---", a JSON
+// array, or a list of resources as printed by 'get'. Only the JSON and YAML files of a
+// directory are read, in lexical order, and subdirectories are read if recursive is set.
+// Directories that hold a ".verifyignore" file are skipped.
+func LoadResourceObjects(cmd *cobra.Command, path string, recursive bool) ([]*SourcedResourceObject, error) {
+	vc := contextx.GetVerifyContext(cmd.Context())
+
+	if path == "-" {
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			vc.Logger.Errorf("unable to read from stdin; err=%v", err)
+			return nil, err
+		}
+
+		return decodeResourceObjects(b, "stdin", "yaml")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		vc.Logger.Errorf("unable to read file; filename=%s, err=%v", path, err)
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return loadResourceFile(cmd, path)
+	}
+
+	objects := []*SourcedResourceObject{}
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if file != path && (!recursive || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(file, IgnoreFileName)); err == nil {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !isResourceFile(file) {
+			return nil
+		}
+
+		fileObjects, err := loadResourceFile(cmd, file)
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, fileObjects...)
+		return nil
+	})
+
+	if err != nil {
+		vc.Logger.Errorf("unable to read the directory; dir=%s, err=%v", path, err)
+		return nil, err
+	}
+
+	if len(objects) == 0 {
+		return nil, errorsx.G11NError("No resources found in the directory '%s'.", path)
+	}
+
+	return objects, nil
+}
+
+func loadResourceFile(cmd *cobra.Command, file string) ([]*SourcedResourceObject, error) {
+	vc := contextx.GetVerifyContext(cmd.Context())
+	b, err := os.ReadFile(file)
+	if err != nil {
+		vc.Logger.Errorf("unable to read file; filename=%s, err=%v", file, err)
+		return nil, err
+	}
+
+	format := "yaml"
+	if strings.HasSuffix(file, ".json") {
+		format = "json"
+	}
+
+	objects, err := decodeResourceObjects(b, file, format)
+	if err != nil {
+		vc.Logger.Errorf("unable to unmarshal the objects; filename=%s, err=%v", file, err)
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// document is a resource object or a list of resource objects.
+type document struct {
+	Kind       string                  `json:"kind" yaml:"kind"`
+	APIVersion string                  `json:"apiVersion" yaml:"apiVersion"`
+	Metadata   *ResourceObjectMetadata `json:"metadata" yaml:"metadata"`
+	Data       interface{}             `json:"data" yaml:"data"`
+	Items      []*ResourceObject       `json:"items" yaml:"items"`
+}
+
+// resourceObjects returns the items of a list as printed by 'get', or the resource.
+func (d *document) resourceObjects() []*ResourceObject {
+	if d.Kind == ResourceTypePrefix+"List" {
+		return d.Items
+	}
+
+	return []*ResourceObject{{
+		Kind:       d.Kind,
+		APIVersion: d.APIVersion,
+		Metadata:   d.Metadata,
+		Data:       d.Data,
+	}}
+}
+
+func decodeResourceObjects(b []byte, source string, format string) ([]*SourcedResourceObject, error) {
+	docs := []*document{}
+	if format == "json" {
+		if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(b, &docs); err != nil {
+				return nil, errorsx.G11NError("unable to parse '%s'; err=%v", source, err)
+			}
+		} else {
+			doc := &document{}
+			if err := json.Unmarshal(b, doc); err != nil {
+				return nil, errorsx.G11NError("unable to parse '%s'; err=%v", source, err)
+			}
+
+			docs = append(docs, doc)
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(b))
+		for {
+			node := &yaml.Node{}
+			if err := decoder.Decode(node); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, errorsx.G11NError("unable to parse '%s'; err=%v", source, err)
+			}
+
+			nodeDocs, err := decodeYAMLDocument(node)
+			if err != nil {
+				return nil, errorsx.G11NError("unable to parse '%s'; err=%v", source, err)
+			}
+
+			docs = append(docs, nodeDocs...)
+		}
+	}
+
+	objects := []*ResourceObject{}
+	for _, doc := range docs {
+		if doc != nil {
+			objects = append(objects, doc.resourceObjects()...)
+		}
+	}
+
+	sourced := []*SourcedResourceObject{}
+	for i, obj := range objects {
+		s := source
+		if len(objects) > 1 {
+			s += "#" + strconv.Itoa(i+1)
+		}
+
+		sourced = append(sourced, &SourcedResourceObject{ResourceObject: obj, Source: s})
+	}
+
+	return sourced, nil
+}
+
+// decodeYAMLDocument decodes a document that is a resource or a sequence of resources.
+// Empty documents are skipped.
+func decodeYAMLDocument(node *yaml.Node) ([]*document, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		docs := []*document{}
+		if err := node.Decode(&docs); err != nil {
+			return nil, err
+		}
+
+		return docs, nil
+	case yaml.MappingNode:
+		doc := &document{}
+		if err := node.Decode(doc); err != nil {
+			return nil, err
+		}
+
+		return []*document{doc}, nil
+	case yaml.DocumentNode:
+		// empty document
+		return nil, nil
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil, nil
+		}
+	}
+
+	return nil, errorsx.G11NError("a document must be a resource or a list of resources")
+}
+
+// Name returns the name of the resource, taken from the metadata or from the field of
+// the data that names resources of its kind.
+func (r *ResourceObject) Name() string {
+	if r.Metadata != nil && len(r.Metadata.Name) > 0 {
+		return r.Metadata.Name
+	}
+
+	data, ok := r.Data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	for _, key := range []string{"userName", "displayName", "instanceName", "clientName", "name"} {
+		if name, ok := data[key].(string); ok && len(name) > 0 {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// DataMap returns the data of the resource as a map, as expected by the commands that
+// create and update resources.
+func (r *ResourceObject) DataMap() (map[string]interface{}, error) {
+	data, ok := r.Data.(map[string]interface{})
+	if !ok {
+		return nil, errorsx.G11NError("The resource has no 'data' object.")
+	}
+
+	return data, nil
+}
+
+// RunEach runs the operation on each resource. The error of a single resource is
+// returned as is. When there are several, all of them are run and a summary of the
+// outcome of each resource is written, with an error if any failed.
+func RunEach(cmd *cobra.Command, objects []*SourcedResourceObject, operation func(obj *SourcedResourceObject) error) error {
+	if len(objects) == 1 {
+		return operation(objects[0])
+	}
+
+	results := []*ResourceResult{}
+	for _, obj := range objects {
+		results = append(results, &ResourceResult{Object: obj, Err: operation(obj)})
+	}
+
+	return WriteSummary(cmd, results)
+}
+
+// WriteSummary writes the outcome of each resource and returns an error if any failed.
+func WriteSummary(cmd *cobra.Command, results []*ResourceResult) error {
+	failed := 0
+	lines := []string{}
+	for _, result := range results {
+		status := i18n.Translate("OK")
+		if result.Err != nil {
+			status = i18n.Translate("FAILED")
+			failed++
+		}
+
+		line := "  " + status + "\t" + result.Object.Source + "\t" + result.Object.Kind
+		if name := result.Object.Name(); len(name) > 0 {
+			line += " '" + name + "'"
+		}
+
+		if result.Err != nil {
+			line += ": " + strings.ReplaceAll(result.Err.Error(), "\n", " ")
+		}
+
+		lines = append(lines, line)
+	}
+
+	cmdutil.WriteString(cmd, "")
+	cmdutil.WriteString(cmd, i18n.TranslateWithArgs("Summary: %d succeeded, %d failed.", len(results)-failed, failed))
+	for _, line := range lines {
+		cmdutil.WriteString(cmd, line)
+	}
+
+	if failed > 0 {
+		return errorsx.G11NError("%d of %d resources failed.", failed, len(results))
+	}
+
+	return nil
+}
+
+func isResourceFile(file string) bool {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,236 @@
+package resource
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	"github.com/ibm-verify/verify-sdk-go/x/logx"
+)
+
+func TestLoadResourceObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		path      string
+		stdin     string
+		recursive bool
+
+		// want lists the source, kind and name of each object
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "reads the documents of a YAML file",
+			files: map[string]string{
+				"users.yaml": `
+kind: IBMVerifyUser
+data:
+  userName: alice
+---
+---
+kind: IBMVerifyUser
+data:
+  userName: bob
+`,
+			},
+			path: "users.yaml",
+			want: []string{
+				"users.yaml#1 IBMVerifyUser alice",
+				"users.yaml#2 IBMVerifyUser bob",
+			},
+		},
+		{
+			name: "reads a YAML sequence",
+			files: map[string]string{
+				"groups.yml": `
+- kind: IBMVerifyGroup
+  data:
+    displayName: admins
+- kind: IBMVerifyGroup
+  data:
+    displayName: users
+`,
+			},
+			path: "groups.yml",
+			want: []string{
+				"groups.yml#1 IBMVerifyGroup admins",
+				"groups.yml#2 IBMVerifyGroup users",
+			},
+		},
+		{
+			name: "reads a JSON array",
+			files: map[string]string{
+				"clients.json": `[
+  {"kind": "IBMVerifyAPIClient", "data": {"clientName": "cli"}},
+  {"kind": "IBMVerifyAPIClient", "data": {"clientName": "ci"}}
+]`,
+			},
+			path: "clients.json",
+			want: []string{
+				"clients.json#1 IBMVerifyAPIClient cli",
+				"clients.json#2 IBMVerifyAPIClient ci",
+			},
+		},
+		{
+			name: "reads a single JSON object",
+			files: map[string]string{
+				"attribute.json": `{"kind": "IBMVerifyAttribute", "metadata": {"name": "mobile"}}`,
+			},
+			path: "attribute.json",
+			want: []string{
+				"attribute.json IBMVerifyAttribute mobile",
+			},
+		},
+		{
+			name: "reads the items of a list printed by get",
+			files: map[string]string{
+				"list.yaml": `
+kind: IBMVerifyList
+apiVersion: "2.0"
+metadata:
+  total: 2
+items:
+  - kind: IBMVerifyUser
+    metadata:
+      name: alice
+  - kind: IBMVerifyUser
+    metadata:
+      name: bob
+`,
+			},
+			path: "list.yaml",
+			want: []string{
+				"list.yaml#1 IBMVerifyUser alice",
+				"list.yaml#2 IBMVerifyUser bob",
+			},
+		},
+		{
+			name:  "reads stdin",
+			path:  "-",
+			stdin: "kind: IBMVerifyUser\ndata:\n  userName: alice\n",
+			want: []string{
+				"stdin IBMVerifyUser alice",
+			},
+		},
+		{
+			name: "reads the resource files of a directory in order",
+			files: map[string]string{
+				"dir/b.yaml":     "kind: IBMVerifyUser\ndata:\n  userName: bob\n",
+				"dir/a.json":     `{"kind": "IBMVerifyUser", "data": {"userName": "alice"}}`,
+				"dir/notes.txt":  "not a resource",
+				"dir/sub/c.yaml": "kind: IBMVerifyUser\ndata:\n  userName: carol\n",
+			},
+			path: "dir",
+			want: []string{
+				"dir/a.json IBMVerifyUser alice",
+				"dir/b.yaml IBMVerifyUser bob",
+			},
+		},
+		{
+			name: "reads the subdirectories if recursive",
+			files: map[string]string{
+				"dir/b.yaml":               "kind: IBMVerifyUser\ndata:\n  userName: bob\n",
+				"dir/sub/c.yaml":           "kind: IBMVerifyUser\ndata:\n  userName: carol\n",
+				"dir/.hidden/d.yaml":       "kind: IBMVerifyUser\ndata:\n  userName: dan\n",
+				"dir/themes/.verifyignore": "",
+				"dir/themes/theme.yaml":    "not: a resource\n",
+			},
+			path:      "dir",
+			recursive: true,
+			want: []string{
+				"dir/b.yaml IBMVerifyUser bob",
+				"dir/sub/c.yaml IBMVerifyUser carol",
+			},
+		},
+		{
+			name: "skips a directory with an ignore file",
+			files: map[string]string{
+				"dir/.verifyignore": "",
+				"dir/a.yaml":        "kind: IBMVerifyUser\ndata:\n  userName: alice\n",
+			},
+			path:    "dir",
+			wantErr: "No resources found in the directory",
+		},
+		{
+			name: "rejects a document that is not a resource",
+			files: map[string]string{
+				"bad.yaml": "just a string\n",
+			},
+			path:    "bad.yaml",
+			wantErr: "a document must be a resource or a list of resources",
+		},
+		{
+			name:    "rejects a missing file",
+			path:    "missing.yaml",
+			wantErr: "no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				file := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := newTestCommand(t)
+			cmd.SetIn(strings.NewReader(tt.stdin))
+
+			path := tt.path
+			if path != "-" {
+				path = filepath.Join(dir, path)
+			}
+
+			objects, err := LoadResourceObjects(cmd, path, tt.recursive)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadResourceObjects() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("LoadResourceObjects() error = %v", err)
+			}
+
+			got := []string{}
+			for _, obj := range objects {
+				source := strings.TrimPrefix(filepath.ToSlash(obj.Source), filepath.ToSlash(dir)+"/")
+				got = append(got, source+" "+obj.Kind+" "+obj.Name())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadResourceObjects() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestCommand(t *testing.T) *cobra.Command {
+	t.Helper()
+	ctx, err := contextx.NewContextWithVerifyContext(context.Background(), logx.NewLoggerWithWriter("test", slog.LevelError, io.Discard))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(ctx)
+	return cmd
+}
@@ -39,6 +39,13 @@ type ResourceObjectMetadata struct {
 	Page  int    `json:"page,omitempty" yaml:"page,omitempty"`
 	Total int    `json:"total,omitempty" yaml:"total,omitempty"`
 	Count int    `json:"count,omitempty" yaml:"count,omitempty"`
+
+	// Tenant is set when resources are fetched from several tenants.
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+
+	// LastApplied is the hash of the data last applied to the resource with 'apply', as
+	// stored on the tenant.
+	LastApplied string `json:"lastApplied,omitempty" yaml:"lastApplied,omitempty"`
 }
 
 func (r *ResourceObject) LoadFromFile(cmd *cobra.Command, file string, format string) error {
@@ -9,6 +9,7 @@ import (
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
 	"github.com/ibm-verify/verify-sdk-go/pkg/i18n"
 	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
 	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
 	"github.com/ibm-verify/verifyctl/pkg/util/templates"
 	"github.com/spf13/cobra"
@@ -17,7 +18,6 @@ import (
 const (
 	themesUsage         = `theme [flags]`
 	themesMessagePrefix = "SetTheme"
-	themesEntitlements  = "manageTemplates (Manage templates and themes)"
 	themeResourceName   = "theme"
 )
 
@@ -113,11 +113,11 @@ func (o *themesOptions) Validate(cmd *cobra.Command, args []string) error {
 
 func (o *themesOptions) Run(cmd *cobra.Command, args []string) error {
 	if o.entitlements {
-		cmdutil.WriteString(cmd, entitlementsMessage+"  "+themesEntitlements)
+		cmdutil.WriteString(cmd, entitlementsMessage+"  "+entitlements.Describe(entitlements.VerbSet, entitlements.ResourceThemes))
 		return nil
 	}
 
-	_, err := o.config.SetAuthToContext(cmd.Context())
+	_, err := entitlements.SetAuthToContext(cmd.Context(), o.config, entitlements.VerbSet, entitlements.ResourceThemes)
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
 	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
@@ -13,37 +16,106 @@ import (
 )
 
 const (
-	apiVersion  = "1.0"
-	kind        = "Config"
-	fileName    = "config"
-	defaultPerm = os.ModePerm
+	apiVersion = "1.1"
+	kind       = "Config"
+	fileName   = "config"
+
+	// lockFileName is locked while the config file is changed.
+	lockFileName = "config.lock"
 )
 
+// updateMu serializes the changes made to the file within the process.
+var updateMu sync.Mutex
+
 type CLIConfig struct {
-	APIVersion    string        `yaml:"apiVersion"`
-	Kind          string        `yaml:"kind"`
-	CurrentTenant string        `yaml:"tenant"`
-	Auth          []*AuthConfig `yaml:"auth"`
+	APIVersion     string           `json:"apiVersion" yaml:"apiVersion"`
+	Kind           string           `json:"kind" yaml:"kind"`
+	CurrentTenant  string           `json:"tenant" yaml:"tenant"`
+	CurrentContext string           `json:"currentContext,omitempty" yaml:"currentContext,omitempty"`
+	Contexts       []*ContextConfig `json:"contexts,omitempty" yaml:"contexts,omitempty"`
+	Auth           []*AuthConfig    `json:"auth" yaml:"auth"`
+
+	// CredentialStore is the backend used to save secrets. The secrets are kept
+	// in this file when it is empty.
+	CredentialStore string `json:"credentialStore,omitempty" yaml:"credentialStore,omitempty"`
+
+	// contextOverride is set using the global '--context' flag and only
+	// applies to the current invocation. It is never persisted.
+	contextOverride string
+
+	// credentialOverride is set using the global '--credential' flag and only
+	// applies to the current invocation. It is never persisted.
+	credentialOverride string
+
+	// tenantView is set on the views returned by ForTenant and takes precedence
+	// over the current tenant.
+	tenantView string
+
+	// credentialOverrides and ephemeralAuth hold credentials that are provided
+	// for the current invocation. They are never persisted.
+	credentialOverrides *CredentialOverrides
+	ephemeralAuth       *AuthConfig
+
+	store CredentialStore
+
+	// unlocked is set once the secrets held by the credential store are loaded. The
+	// store is only unlocked when a secret is needed, as it may ask for a passphrase.
+	unlocked bool
+
+	// loadErr is set if the file does not match the schema. Only commands that
+	// diagnose the file can run in that case.
+	loadErr error
+
+	// upgrade is set if the file was written by an older version. The file is
+	// upgraded in place and the original is backed up.
+	upgrade *upgrade
+}
+
+// ContextConfig binds a name to a tenant, whose credentials are held in
+// the matching AuthConfig.
+type ContextConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Tenant string `json:"tenant" yaml:"tenant"`
 }
 
 type AuthConfig struct {
-	Tenant string `yaml:"tenant"`
-	Token  string `yaml:"token"`
-	User   bool   `yaml:"isUser"`
+	Tenant       string         `json:"tenant" yaml:"tenant"`
+	Name         string         `json:"name,omitempty" yaml:"name,omitempty"`
+	Server       string         `json:"server,omitempty" yaml:"server,omitempty"`
+	Token        string         `json:"token" yaml:"token"`
+	User         bool           `json:"isUser" yaml:"isUser"`
+	RefreshToken string         `json:"refreshToken,omitempty" yaml:"refreshToken,omitempty"`
+	Expiry       time.Time      `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	Scopes       []string       `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Client       *ClientConfig  `json:"client,omitempty" yaml:"client,omitempty"`
+	SaveSecret   bool           `json:"saveSecret,omitempty" yaml:"saveSecret,omitempty"`
+	Exec         *ExecConfig    `json:"exec,omitempty" yaml:"exec,omitempty"`
+	Network      *NetworkConfig `json:"network,omitempty" yaml:"network,omitempty"`
+
+	// Entitlements caches the entitlements granted to the token, as reported by
+	// token introspection. It is cleared whenever the token changes.
+	Entitlements []string `json:"entitlements,omitempty" yaml:"entitlements,omitempty"`
+
+	// ephemeral is set for sessions built from flags or environment variables.
+	ephemeral bool
 }
 
 func NewCLIConfig() *CLIConfig {
 	return &CLIConfig{
 		APIVersion: apiVersion,
 		Kind:       kind,
+		Contexts:   []*ContextConfig{},
 		Auth:       []*AuthConfig{},
 	}
 }
 
 func (o *CLIConfig) AddAuth(config *AuthConfig) {
+	// make sure the tenant can be selected by name
+	o.ensureContext(config.Tenant)
+
 	// check if it already exists and replace if so
 	for _, c := range o.Auth {
-		if c.Tenant == config.Tenant {
+		if c.Tenant == config.Tenant && c.Name == config.Name {
 			// replace
 			c.Merge(config)
 			return
@@ -56,56 +128,432 @@ func (o *CLIConfig) AddAuth(config *AuthConfig) {
 
 func (o *CLIConfig) SetCurrentTenant(tenant string) {
 	o.CurrentTenant = tenant
+
+	// keep the current context pointing at the tenant
+	if c := o.GetContext(o.CurrentContext); c != nil && c.Tenant == tenant {
+		return
+	}
+
+	o.CurrentContext = ""
+	if c := o.ensureContext(tenant); c != nil {
+		o.CurrentContext = c.Name
+	}
+}
+
+// GetContext returns the context with the given name or nil if one does not exist.
+func (o *CLIConfig) GetContext(name string) *ContextConfig {
+	if name == "" {
+		return nil
+	}
+
+	for _, c := range o.Contexts {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// UseContext switches the current context and tenant to the named context.
+func (o *CLIConfig) UseContext(name string) error {
+	c := o.GetContext(name)
+	if c == nil {
+		return errorsx.G11NError("No context exists with the name '%s'.", name)
+	}
+
+	o.CurrentContext = c.Name
+	o.CurrentTenant = c.Tenant
+	return nil
+}
+
+// RenameContext changes the name of an existing context.
+func (o *CLIConfig) RenameContext(oldName string, newName string) error {
+	c := o.GetContext(oldName)
+	if c == nil {
+		return errorsx.G11NError("No context exists with the name '%s'.", oldName)
+	}
+
+	if o.GetContext(newName) != nil {
+		return errorsx.G11NError("A context with the name '%s' already exists.", newName)
+	}
+
+	c.Name = newName
+	if o.CurrentContext == oldName {
+		o.CurrentContext = newName
+	}
+
+	return nil
+}
+
+// DeleteContext removes the named context. The credentials of the tenant are
+// also removed if no other context refers to it.
+func (o *CLIConfig) DeleteContext(name string) error {
+	c := o.GetContext(name)
+	if c == nil {
+		return errorsx.G11NError("No context exists with the name '%s'.", name)
+	}
+
+	contexts := []*ContextConfig{}
+	tenantInUse := false
+	for _, ctx := range o.Contexts {
+		if ctx.Name == name {
+			continue
+		}
+
+		if ctx.Tenant == c.Tenant {
+			tenantInUse = true
+		}
+
+		contexts = append(contexts, ctx)
+	}
+
+	o.Contexts = contexts
+	if !tenantInUse {
+		o.removeAuth(c.Tenant)
+	}
+
+	if o.CurrentContext == name {
+		o.CurrentContext = ""
+		o.CurrentTenant = ""
+	}
+
+	return nil
+}
+
+// RemoveCredential removes the named credential of the tenant. The contexts are
+// removed with the last credential of the tenant.
+func (o *CLIConfig) RemoveCredential(tenant string, name string) {
+	auth := []*AuthConfig{}
+	remaining := false
+	for _, c := range o.Auth {
+		if c.Tenant == tenant && c.Name == name {
+			continue
+		}
+
+		remaining = remaining || c.Tenant == tenant
+		auth = append(auth, c)
+	}
+
+	o.Auth = auth
+	if !remaining {
+		o.RemoveAuth(tenant)
+	}
+}
+
+// RemoveAuth removes all the credentials of the tenant and the contexts that refer to it.
+func (o *CLIConfig) RemoveAuth(tenant string) {
+	o.removeAuth(tenant)
+
+	contexts := []*ContextConfig{}
+	for _, c := range o.Contexts {
+		if c.Tenant != tenant {
+			contexts = append(contexts, c)
+		}
+	}
+
+	o.Contexts = contexts
+	if o.CurrentTenant == tenant {
+		o.CurrentContext = ""
+		o.CurrentTenant = ""
+	}
+}
+
+// SetContextOverride selects the context used for the current invocation without
+// changing the persisted current context.
+func (o *CLIConfig) SetContextOverride(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	if o.GetContext(name) == nil {
+		return errorsx.G11NError("No context exists with the name '%s'.", name)
+	}
+
+	o.contextOverride = name
+	return nil
+}
+
+// SetCredentialOverride selects the named credential of the tenant for the current
+// invocation, instead of selecting one based on the entitlements that are needed.
+func (o *CLIConfig) SetCredentialOverride(name string) {
+	o.credentialOverride = name
+}
+
+// CredentialOverride returns the name of the credential selected for the current invocation.
+func (o *CLIConfig) CredentialOverride() string {
+	return o.credentialOverride
+}
+
+// CurrentContextName returns the name of the context used by the current invocation.
+func (o *CLIConfig) CurrentContextName() string {
+	if len(o.contextOverride) > 0 {
+		return o.contextOverride
+	}
+
+	return o.CurrentContext
 }
 
 func (o *CLIConfig) LoadFromFile() (*CLIConfig, error) {
+	if err := o.load(); err != nil {
+		return o, err
+	}
+
+	// files written by older versions are upgraded in place
+	if o.upgrade != nil {
+		if err := o.Update(func(c *CLIConfig) error { return nil }); err != nil {
+			return o, err
+		}
+	}
+
+	return o, nil
+}
+
+func (o *CLIConfig) load() error {
 	configDir, err := cmdutil.GetDir()
 	if err != nil {
-		return o, err
+		return err
 	}
 
 	configFile := filepath.Join(configDir, fileName)
 	if _, err := os.Stat(configFile); errors.Is(err, os.ErrNotExist) {
 		// do nothing. the file will get created when something needs to be added.
-		return o, nil
+		return nil
 	}
 
 	data, err := os.ReadFile(configFile)
 	if err != nil {
-		return o, err
+		return err
 	}
 
-	if err = yaml.Unmarshal(data, o); err != nil {
-		return o, err
+	if err = o.decodeConfig(configFile, data); err != nil {
+		o.loadErr = err
+		return err
 	}
 
-	return o, nil
+	return nil
 }
 
-func (o *CLIConfig) PersistFile() (*CLIConfig, error) {
-	data, err := yaml.Marshal(o)
-	if err != nil {
-		return o, err
+// LoadError returns the error found when the file was loaded, if any.
+func (o *CLIConfig) LoadError() error {
+	return o.loadErr
+}
+
+// Update applies a change to the configuration file. The file is locked against other
+// verifyctl processes, loaded again so that their changes are kept, changed using the
+// mutate function and written back. The configuration in memory is replaced with the
+// result. All changes to the file must go through Update.
+func (o *CLIConfig) Update(mutate func(c *CLIConfig) error) error {
+	// never replace a file that could not be read
+	if o.loadErr != nil {
+		return o.loadErr
 	}
 
 	configDir, err := cmdutil.CreateOrGetDir()
 	if err != nil {
-		return o, err
+		return err
+	}
+
+	// the file lock does not exclude the goroutines of this process
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	unlock, err := cmdutil.LockFile(filepath.Join(configDir, lockFileName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	latest := NewCLIConfig()
+	// reuse the credential store, so that a passphrase is not asked for again
+	latest.store = o.store
+	if err := latest.load(); err != nil {
+		return err
 	}
 
+	if o.unlocked {
+		if err := latest.UnlockCredentials(); err != nil {
+			return err
+		}
+	}
+
+	if err := mutate(latest); err != nil {
+		return err
+	}
+
+	if err := latest.persistFile(configDir); err != nil {
+		return err
+	}
+
+	o.APIVersion = latest.APIVersion
+	o.Kind = latest.Kind
+	o.CurrentTenant = latest.CurrentTenant
+	o.CurrentContext = latest.CurrentContext
+	o.Contexts = latest.Contexts
+	o.Auth = latest.Auth
+	o.CredentialStore = latest.CredentialStore
+	o.store = latest.store
+	o.unlocked = latest.unlocked
+	o.upgrade = nil
+	return nil
+}
+
+// Tenants returns the tenants that have a saved session, in the order they were added.
+func (o *CLIConfig) Tenants() []string {
+	tenants := []string{}
+	for _, c := range o.Auth {
+		if !slices.Contains(tenants, c.Tenant) {
+			tenants = append(tenants, c.Tenant)
+		}
+	}
+
+	return tenants
+}
+
+// ForTenant returns a view of the configuration in which the tenant is the current
+// tenant, so that a command can run against several tenants at once. Changes made
+// through the view, such as renewed tokens, are saved to the file.
+func (o *CLIConfig) ForTenant(tenant string) *CLIConfig {
+	view := *o
+	view.tenantView = tenant
+	view.contextOverride = ""
+	return &view
+}
+
+// persistFile writes the configuration to the file. It is called with the file locked.
+func (o *CLIConfig) persistFile(configDir string) error {
 	configFile := filepath.Join(configDir, fileName)
-	if err = os.WriteFile(configFile, data, defaultPerm); err != nil {
-		return o, err
+	if o.upgrade != nil {
+		if err := o.upgrade.backup(configFile); err != nil {
+			return err
+		}
 	}
 
-	return o, nil
+	persisted, err := o.saveCredentials()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(persisted)
+	if err != nil {
+		return err
+	}
+
+	if err := cmdutil.WritePrivateFile(configFile, data); err != nil {
+		return err
+	}
+
+	o.upgrade = nil
+	return nil
+}
+
+// SetCredentialStore switches the backend used to save secrets and moves the
+// existing secrets to it.
+func (o *CLIConfig) SetCredentialStore(name string) error {
+	newStore, err := NewCredentialStore(name)
+	if err != nil {
+		return err
+	}
+
+	var oldStore CredentialStore
+	err = o.Update(func(c *CLIConfig) error {
+		store, err := c.getCredentialStore()
+		if err != nil {
+			return err
+		}
+
+		if store.Name() == newStore.Name() {
+			return nil
+		}
+
+		// the secrets are moved to the new store
+		if err := c.UnlockCredentials(); err != nil {
+			return err
+		}
+
+		oldStore = store
+		c.CredentialStore = newStore.Name()
+		c.store = newStore
+		return nil
+	})
+
+	if err != nil || oldStore == nil {
+		return err
+	}
+
+	return oldStore.Remove()
+}
+
+// UpdateAuth saves the changes made to a session, such as a renewed token. A session
+// that was removed by another process in the meantime is not added back.
+func (o *CLIConfig) UpdateAuth(auth *AuthConfig) error {
+	if auth.IsEphemeral() {
+		// ephemeral sessions are never written to the file
+		return nil
+	}
+
+	return o.Update(func(c *CLIConfig) error {
+		for _, a := range c.Auth {
+			if a.Tenant == auth.Tenant && a.Name == auth.Name {
+				a.Merge(auth)
+			}
+		}
+
+		return nil
+	})
 }
 
 func (o *CLIConfig) GetCurrentAuth() (*AuthConfig, error) {
+	if auth := o.getEphemeralAuth(); auth != nil {
+		if len(auth.Tenant) == 0 {
+			return nil, errorsx.G11NError("The tenant is required. Set the '%s' environment variable or the '--tenant' flag.", TenantEnvVar)
+		}
+
+		return auth, nil
+	}
+
+	credentials, err := o.CurrentCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials[0], nil
+}
+
+// CurrentCredentials returns the credentials of the current tenant that commands can
+// use, in the order they were added. Only the credential selected using
+// SetCredentialOverride is returned, if any, and only the ephemeral session if
+// credentials are provided using flags or environment variables.
+func (o *CLIConfig) CurrentCredentials() ([]*AuthConfig, error) {
+	if auth := o.getEphemeralAuth(); auth != nil {
+		auth, err := o.GetCurrentAuth()
+		if err != nil {
+			return nil, err
+		}
+
+		return []*AuthConfig{auth}, nil
+	}
+
+	tenant := o.currentTenant()
+	credentials := []*AuthConfig{}
 	for _, c := range o.Auth {
-		if c.Tenant == o.CurrentTenant {
-			return c, nil
+		if c.Tenant != tenant {
+			continue
 		}
+
+		if len(o.credentialOverride) > 0 && c.Name != o.credentialOverride {
+			continue
+		}
+
+		credentials = append(credentials, c)
+	}
+
+	if len(credentials) > 0 {
+		return credentials, nil
+	}
+
+	if len(o.credentialOverride) > 0 {
+		return nil, errorsx.G11NError("No credential named '%s' exists for the tenant '%s'.", o.credentialOverride, tenant)
 	}
 
 	return nil, errorsx.G11NError("No login session available. Use:\n  verifyctl login -h")
@@ -117,16 +565,267 @@ func (o *CLIConfig) SetAuthToContext(ctx context.Context) (*AuthConfig, error) {
 		return nil, err
 	}
 
+	if err := o.ActivateAuth(ctx, auth); err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+// ActivateAuth makes the credential the one used by the SDK clients. The token is
+// renewed if it has expired.
+func (o *CLIConfig) ActivateAuth(ctx context.Context, auth *AuthConfig) error {
+	if err := auth.ApplyNetwork(); err != nil {
+		return err
+	}
+
+	if !auth.IsEphemeral() {
+		if err := o.UnlockCredentials(); err != nil {
+			return err
+		}
+	}
+
+	// renew the token before it expires, so long runs are not interrupted
+	if auth.IsExpired() || len(auth.Token) == 0 {
+		if err := o.RenewAuth(ctx, auth); err != nil {
+			return err
+		}
+	}
+
 	// hydrate the verify context with current auth information
 	vc := contextx.GetVerifyContext(ctx)
 	vc.Tenant = auth.Tenant
 	vc.Token = auth.Token
 
-	return auth, nil
+	return nil
+}
+
+// RenewAuth gets a new token for the credential and saves it, regardless of the expiry
+// of the current token.
+func (o *CLIConfig) RenewAuth(ctx context.Context, auth *AuthConfig) error {
+	vc := contextx.GetVerifyContext(ctx)
+	if !auth.IsEphemeral() {
+		if err := o.UnlockCredentials(); err != nil {
+			return err
+		}
+	}
+
+	if err := auth.Refresh(ctx); err != nil {
+		vc.Logger.Errorf("unable to refresh the token; tenant=%s, err=%v", auth.Tenant, err)
+		return err
+	}
+
+	// ephemeral sessions are minted on demand and never written to the file
+	return o.UpdateAuth(auth)
+}
+
+func (o *CLIConfig) getCredentialStore() (CredentialStore, error) {
+	if o.store != nil {
+		return o.store, nil
+	}
+
+	store, err := NewCredentialStore(o.CredentialStore)
+	if err != nil {
+		return nil, err
+	}
+
+	o.store = store
+	return store, nil
+}
+
+// UnlockCredentials populates the secrets held by the credential store, which may ask
+// for the passphrase. It is called when a secret is first needed, so that commands that
+// do not use the saved sessions never unlock the store. Secrets already set, such as
+// those of a new login, are kept.
+func (o *CLIConfig) UnlockCredentials() error {
+	if o.unlocked {
+		return nil
+	}
+
+	store, err := o.getCredentialStore()
+	if err != nil {
+		return err
+	}
+
+	if store.Name() != PlaintextStore && len(o.Auth) > 0 {
+		credentials, err := store.Load()
+		if err != nil {
+			return err
+		}
+
+		for _, c := range o.Auth {
+			if creds, ok := credentials[c.credentialKey()]; ok && !c.hasCredentials() {
+				c.setCredentials(creds)
+			}
+		}
+	}
+
+	o.unlocked = true
+	return nil
+}
+
+// saveCredentials hands the secrets to the credential store and returns the
+// config that can be written to the file.
+func (o *CLIConfig) saveCredentials() (*CLIConfig, error) {
+	store, err := o.getCredentialStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if store.Name() == PlaintextStore {
+		// the client secrets are only written to the file if the user opted in
+		persisted := *o
+		persisted.Auth = []*AuthConfig{}
+		for _, c := range o.Auth {
+			auth := *c
+			if auth.Client != nil && !auth.SaveSecret {
+				auth.Client = auth.Client.withoutSecrets()
+			}
+
+			persisted.Auth = append(persisted.Auth, &auth)
+		}
+
+		return &persisted, nil
+	}
+
+	// the saved secrets are left as is if none was loaded or added. Otherwise they are
+	// loaded, so that the secrets of the other sessions are written back.
+	if !o.unlocked && slices.ContainsFunc(o.Auth, (*AuthConfig).hasCredentials) {
+		if err := o.UnlockCredentials(); err != nil {
+			return nil, err
+		}
+	}
+
+	persisted := *o
+	persisted.Auth = []*AuthConfig{}
+	credentials := map[string]*Credentials{}
+	for _, c := range o.Auth {
+		credentials[c.credentialKey()] = c.getCredentials()
+
+		auth := *c
+		auth.setCredentials(&Credentials{})
+		persisted.Auth = append(persisted.Auth, &auth)
+	}
+
+	if !o.unlocked {
+		return &persisted, nil
+	}
+
+	if err := store.Save(credentials); err != nil {
+		return nil, err
+	}
+
+	return &persisted, nil
+}
+
+// currentTenant returns the tenant of the context used by the current invocation.
+func (o *CLIConfig) currentTenant() string {
+	if len(o.tenantView) > 0 {
+		return o.tenantView
+	}
+
+	if tenant := o.tenantOverride(); len(tenant) > 0 {
+		return tenant
+	}
+
+	if c := o.GetContext(o.contextOverride); c != nil {
+		return c.Tenant
+	}
+
+	return o.CurrentTenant
+}
+
+// ensureContext returns the first context for the tenant, creating one named
+// after the tenant if none exists.
+func (o *CLIConfig) ensureContext(tenant string) *ContextConfig {
+	if tenant == "" {
+		return nil
+	}
+
+	for _, c := range o.Contexts {
+		if c.Tenant == tenant {
+			return c
+		}
+	}
+
+	c := &ContextConfig{
+		Name:   tenant,
+		Tenant: tenant,
+	}
+
+	o.Contexts = append(o.Contexts, c)
+	return c
+}
+
+func (o *CLIConfig) removeAuth(tenant string) {
+	auth := []*AuthConfig{}
+	for _, c := range o.Auth {
+		if c.Tenant != tenant {
+			auth = append(auth, c)
+		}
+	}
+
+	o.Auth = auth
+}
+
+// IsEphemeral returns true if the session was built from flags or environment
+// variables and is not saved in the config file.
+func (o *AuthConfig) IsEphemeral() bool {
+	return o.ephemeral
+}
+
+// credentialKey identifies the secrets of the credential in the credential store.
+// Unnamed credentials use the tenant, as in older versions of the client.
+func (o *AuthConfig) credentialKey() string {
+	if len(o.Name) > 0 {
+		return o.Tenant + "#" + o.Name
+	}
+
+	return o.Tenant
+}
+
+func (o *AuthConfig) getCredentials() *Credentials {
+	creds := &Credentials{
+		Token:        o.Token,
+		RefreshToken: o.RefreshToken,
+	}
+
+	if o.Client != nil {
+		creds.ClientSecret = o.Client.ClientSecret
+		creds.PrivateKey = o.Client.PrivateKey
+	}
+
+	return creds
+}
+
+// hasCredentials returns true if any of the secrets of the session is set.
+func (o *AuthConfig) hasCredentials() bool {
+	return *o.getCredentials() != Credentials{}
+}
+
+func (o *AuthConfig) setCredentials(creds *Credentials) {
+	o.Token = creds.Token
+	o.RefreshToken = creds.RefreshToken
+	if o.Client != nil {
+		client := *o.Client
+		client.ClientSecret = creds.ClientSecret
+		client.PrivateKey = creds.PrivateKey
+		o.Client = &client
+	}
 }
 
 func (o *AuthConfig) Merge(c *AuthConfig) {
 	o.Tenant = c.Tenant
+	o.Name = c.Name
+	o.Server = c.Server
 	o.Token = c.Token
 	o.User = c.User
+	o.RefreshToken = c.RefreshToken
+	o.Expiry = c.Expiry
+	o.Scopes = c.Scopes
+	o.Client = c.Client
+	o.SaveSecret = c.SaveSecret
+	o.Exec = c.Exec
+	o.Network = c.Network
+	o.Entitlements = c.Entitlements
 }
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// updateHelperEnvVar runs TestUpdateHelperProcess as a separate verifyctl process.
+const updateHelperEnvVar = "VERIFY_TEST_UPDATE_HELPER"
+
+func TestUpdateParallel(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes int
+		updates   int
+	}{
+		{
+			name:    "goroutines of a process",
+			updates: 20,
+		},
+		{
+			name:      "separate processes",
+			processes: 4,
+			updates:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VERIFY_HOME", t.TempDir())
+			if tt.processes == 0 {
+				addTenants(t, "goroutine", tt.updates)
+			} else {
+				var wg sync.WaitGroup
+				for p := 0; p < tt.processes; p++ {
+					wg.Add(1)
+					go func(p int) {
+						defer wg.Done()
+						cmd := exec.Command(os.Args[0], "-test.run=^TestUpdateHelperProcess$")
+						cmd.Env = append(os.Environ(),
+							updateHelperEnvVar+"=process"+strconv.Itoa(p),
+							"VERIFY_TEST_UPDATES="+strconv.Itoa(tt.updates))
+						if out, err := cmd.CombinedOutput(); err != nil {
+							t.Errorf("process %d failed: %v\n%s", p, err, out)
+						}
+					}(p)
+				}
+
+				wg.Wait()
+			}
+
+			c, err := NewCLIConfig().LoadFromFile()
+			if err != nil {
+				t.Fatalf("LoadFromFile() error = %v", err)
+			}
+
+			want := tt.updates * max(1, tt.processes)
+			if len(c.Auth) != want || len(c.Contexts) != want {
+				t.Errorf("the file has %d sessions and %d contexts, want %d", len(c.Auth), len(c.Contexts), want)
+			}
+		})
+	}
+}
+
+// TestUpdateHelperProcess adds tenants to the file when run by TestUpdateParallel.
+func TestUpdateHelperProcess(t *testing.T) {
+	prefix := os.Getenv(updateHelperEnvVar)
+	if len(prefix) == 0 {
+		t.Skip("run by TestUpdateParallel")
+	}
+
+	updates, _ := strconv.Atoi(os.Getenv("VERIFY_TEST_UPDATES"))
+	addTenants(t, prefix, updates)
+}
+
+// addTenants adds a session for each tenant concurrently, each with its own view of the
+// file, as separate commands would.
+func addTenants(t *testing.T, prefix string, count int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := NewCLIConfig().LoadFromFile()
+			if err != nil {
+				t.Errorf("LoadFromFile() error = %v", err)
+				return
+			}
+
+			tenant := fmt.Sprintf("%s-%d.verify.ibm.com", prefix, i)
+			err = c.Update(func(c *CLIConfig) error {
+				c.AddAuth(&AuthConfig{Tenant: tenant, Token: "token"})
+				return nil
+			})
+
+			if err != nil {
+				t.Errorf("Update() error = %v", err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
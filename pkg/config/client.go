@@ -0,0 +1,75 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+)
+
+const (
+	PrivateKeyJWTAuthType = "private_key_jwt"
+)
+
+// ClientConfig holds the OAuth client used to log in, so that the token can be
+// renewed without running 'auth' again.
+type ClientConfig struct {
+	ClientID       string     `json:"clientId" yaml:"clientId"`
+	ClientAuthType string     `json:"authType,omitempty" yaml:"authType,omitempty"`
+	ClientSecret   string     `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	PrivateKey     string     `json:"key,omitempty" yaml:"key,omitempty"`
+	Certificate    string     `json:"cert,omitempty" yaml:"cert,omitempty"`
+	Algorithm      string     `json:"alg,omitempty" yaml:"alg,omitempty"`
+	KeyID          string     `json:"kid,omitempty" yaml:"kid,omitempty"`
+	Parameters     url.Values `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// ConvertToClient builds the OAuth client for the tenant.
+func (c *ClientConfig) ConvertToClient(tenant string, scopes []string) (*oidc.Client, error) {
+	client := &oidc.Client{
+		Tenant: tenant,
+		Scopes: scopes,
+	}
+
+	if c.ClientAuthType == PrivateKeyJWTAuthType {
+		jwk, err := LoadPrivateKey(c.PrivateKey, c.PrivateKeyOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		client.ClientAuth = NewPrivateKeyJWT(tenant, c.ClientID, jwk)
+	} else {
+		client.ClientAuth = &oidc.ClientSecretPost{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+		}
+	}
+
+	return client, nil
+}
+
+// HasSecret returns true if the client secret or the private key is set.
+func (c *ClientConfig) HasSecret() bool {
+	return len(c.ClientSecret) > 0 || len(c.PrivateKey) > 0
+}
+
+// withoutSecrets returns a copy of the client without the client secret and the private
+// key. A private key read from a file is kept, as only the path is saved.
+func (c *ClientConfig) withoutSecrets() *ClientConfig {
+	client := *c
+	client.ClientSecret = ""
+	if !strings.HasPrefix(client.PrivateKey, "@") {
+		client.PrivateKey = ""
+	}
+
+	return &client
+}
+
+// PrivateKeyOptions returns the options used to load the private key.
+func (c *ClientConfig) PrivateKeyOptions() *PrivateKeyOptions {
+	return &PrivateKeyOptions{
+		Certificate: c.Certificate,
+		Algorithm:   c.Algorithm,
+		KeyID:       c.KeyID,
+	}
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	PlaintextStore     = "plaintext"
+	EncryptedFileStore = "encryptedFile"
+
+	PassphraseEnvVar = "VERIFY_PASSPHRASE"
+
+	credentialsFileName    = "credentials"
+	credentialsFileVersion = 1
+	credentialsAAD         = "verifyctl-credentials"
+
+	// scrypt parameters recommended for interactive logins
+	scryptN      = 32768
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// CredentialStore persists the secrets of the auth sessions, such as tokens and
+// client secrets. The rest of the session is always written to the config file.
+type CredentialStore interface {
+	// Name identifies the backend in the config file.
+	Name() string
+
+	// Load returns the saved credentials keyed by session.
+	Load() (map[string]*Credentials, error)
+
+	// Save replaces the saved credentials.
+	Save(credentials map[string]*Credentials) error
+
+	// Remove deletes any credentials saved by the backend.
+	Remove() error
+}
+
+// Credentials are the secrets of a single auth session.
+type Credentials struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	PrivateKey   string `json:"key,omitempty"`
+}
+
+// NewCredentialStore returns the backend with the given name. An empty name
+// selects the plaintext backend.
+func NewCredentialStore(name string) (CredentialStore, error) {
+	switch name {
+	case "", PlaintextStore:
+		return &plaintextStore{}, nil
+	case EncryptedFileStore:
+		return &encryptedFileStore{
+			passphrase: readPassphrase,
+		}, nil
+	}
+
+	return nil, errorsx.G11NError("Unsupported credential store '%s'. The values supported are '%s' and '%s'.", name, PlaintextStore, EncryptedFileStore)
+}
+
+// plaintextStore keeps the secrets in the config file as is. This is the
+// behaviour of older versions of the client.
+type plaintextStore struct{}
+
+func (s *plaintextStore) Name() string {
+	return PlaintextStore
+}
+
+func (s *plaintextStore) Load() (map[string]*Credentials, error) {
+	return nil, nil
+}
+
+func (s *plaintextStore) Save(_ map[string]*Credentials) error {
+	return nil
+}
+
+func (s *plaintextStore) Remove() error {
+	return nil
+}
+
+// encryptedFileStore writes the secrets to a separate file encrypted with
+// AES-256-GCM. The key is derived from a passphrase using scrypt.
+type encryptedFileStore struct {
+	passphrase func() ([]byte, error)
+
+	cachedPassphrase []byte
+}
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (s *encryptedFileStore) Name() string {
+	return EncryptedFileStore
+}
+
+func (s *encryptedFileStore) Load() (map[string]*Credentials, error) {
+	path, err := s.path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]*Credentials{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	f := &encryptedFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, errorsx.G11NError("The credentials file is corrupted.")
+	}
+
+	if f.Version != credentialsFileVersion {
+		return nil, errorsx.G11NError("Unsupported credentials file version '%d'.", f.Version)
+	}
+
+	aead, err := s.aead(f.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, f.Nonce, f.Ciphertext, []byte(credentialsAAD))
+	if err != nil {
+		return nil, errorsx.G11NError("Unable to decrypt the credentials. Check the passphrase.")
+	}
+
+	credentials := map[string]*Credentials{}
+	if err := json.Unmarshal(plaintext, &credentials); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (s *encryptedFileStore) Save(credentials map[string]*Credentials) error {
+	plaintext, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+
+	f := &encryptedFile{
+		Version: credentialsFileVersion,
+		KDF:     "scrypt",
+		Salt:    make([]byte, saltLen),
+	}
+
+	if _, err := rand.Read(f.Salt); err != nil {
+		return err
+	}
+
+	aead, err := s.aead(f.Salt)
+	if err != nil {
+		return err
+	}
+
+	f.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+
+	f.Ciphertext = aead.Seal(nil, f.Nonce, plaintext, []byte(credentialsAAD))
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	configDir, err := cmdutil.CreateOrGetDir()
+	if err != nil {
+		return err
+	}
+
+	return cmdutil.WritePrivateFile(filepath.Join(configDir, credentialsFileName), data)
+}
+
+func (s *encryptedFileStore) Remove() error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *encryptedFileStore) path() (string, error) {
+	configDir, err := cmdutil.GetDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, credentialsFileName), nil
+}
+
+func (s *encryptedFileStore) aead(salt []byte) (cipher.AEAD, error) {
+	if s.cachedPassphrase == nil {
+		passphrase, err := s.passphrase()
+		if err != nil {
+			return nil, err
+		}
+
+		s.cachedPassphrase = passphrase
+	}
+
+	key, err := scrypt.Key(s.cachedPassphrase, salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// readPassphrase uses the passphrase in the environment or prompts for it when
+// running in a terminal.
+func readPassphrase() ([]byte, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); len(passphrase) > 0 {
+		return []byte(passphrase), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errorsx.G11NError("A passphrase is required to access the credentials. Set the '%s' environment variable.", PassphraseEnvVar)
+	}
+
+	_, _ = fmt.Fprint(os.Stderr, "Passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) == 0 {
+		return nil, errorsx.G11NError("The passphrase cannot be empty.")
+	}
+
+	return passphrase, nil
+}
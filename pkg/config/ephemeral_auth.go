@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+)
+
+const (
+	TenantEnvVar       = "VERIFY_TENANT"
+	TokenEnvVar        = "VERIFY_TOKEN"
+	ClientIDEnvVar     = "VERIFY_CLIENT_ID"
+	ClientSecretEnvVar = "VERIFY_CLIENT_SECRET"
+	PrivateKeyEnvVar   = "VERIFY_PRIVATE_KEY"
+	ServerEnvVar       = "VERIFY_SERVER"
+)
+
+// CredentialOverrides are credentials provided for a single invocation, such as in
+// CI pipelines. They are used to build a session that is never persisted.
+//
+// The sources are checked in the following order, before the config file:
+//
+//  1. Global flags: --tenant, --token, --client-id, --private-key and --server
+//  2. Environment variables: VERIFY_TENANT, VERIFY_TOKEN, VERIFY_CLIENT_ID,
+//     VERIFY_CLIENT_SECRET, VERIFY_PRIVATE_KEY and VERIFY_SERVER
+//
+// Each property is taken from the first source that sets it. A token takes
+// precedence over client credentials. If no tenant is provided, the tenant of
+// the current context is used. A tenant without a token or client ID selects
+// the saved session for that tenant.
+type CredentialOverrides struct {
+	Tenant       string
+	Token        string
+	ClientID     string
+	ClientSecret string
+	PrivateKey   string
+	Server       string
+}
+
+// SetCredentialOverrides sets the credentials provided using flags.
+func (o *CLIConfig) SetCredentialOverrides(flags *CredentialOverrides) {
+	o.credentialOverrides = flags
+	o.ephemeralAuth = nil
+}
+
+// getEphemeralAuth returns the session built from flags and environment variables
+// or nil if no credentials were provided.
+func (o *CLIConfig) getEphemeralAuth() *AuthConfig {
+	if o.ephemeralAuth != nil {
+		return o.ephemeralAuth
+	}
+
+	overrides := &CredentialOverrides{
+		Tenant:       os.Getenv(TenantEnvVar),
+		Token:        os.Getenv(TokenEnvVar),
+		ClientID:     os.Getenv(ClientIDEnvVar),
+		ClientSecret: os.Getenv(ClientSecretEnvVar),
+		PrivateKey:   os.Getenv(PrivateKeyEnvVar),
+		Server:       os.Getenv(ServerEnvVar),
+	}
+
+	if flags := o.credentialOverrides; flags != nil {
+		overrides.Tenant = firstNonEmpty(flags.Tenant, overrides.Tenant)
+		overrides.Token = firstNonEmpty(flags.Token, overrides.Token)
+		overrides.ClientID = firstNonEmpty(flags.ClientID, overrides.ClientID)
+		overrides.ClientSecret = firstNonEmpty(flags.ClientSecret, overrides.ClientSecret)
+		overrides.PrivateKey = firstNonEmpty(flags.PrivateKey, overrides.PrivateKey)
+		overrides.Server = firstNonEmpty(flags.Server, overrides.Server)
+	}
+
+	if len(overrides.Token) == 0 && len(overrides.ClientID) == 0 {
+		return nil
+	}
+
+	tenant := overrides.Tenant
+	if len(tenant) == 0 {
+		tenant = o.currentTenant()
+	}
+
+	auth := &AuthConfig{
+		Tenant:    tenant,
+		Token:     overrides.Token,
+		ephemeral: true,
+	}
+
+	// the server and network settings of a saved session for the tenant still apply
+	for _, c := range o.Auth {
+		if c.Tenant == tenant {
+			auth.Server = c.Server
+			auth.Network = c.Network
+		}
+	}
+
+	if len(overrides.Server) > 0 {
+		auth.Server = overrides.Server
+	}
+
+	if len(overrides.Token) == 0 {
+		auth.Client = &ClientConfig{
+			ClientID:     overrides.ClientID,
+			ClientSecret: overrides.ClientSecret,
+			PrivateKey:   overrides.PrivateKey,
+		}
+
+		if len(overrides.PrivateKey) > 0 {
+			auth.Client.ClientAuthType = PrivateKeyJWTAuthType
+		}
+	}
+
+	o.ephemeralAuth = auth
+	return auth
+}
+
+// tenantOverride returns the tenant provided using the flag or environment variable.
+// A tenant on its own selects the saved session for that tenant.
+func (o *CLIConfig) tenantOverride() string {
+	if o.credentialOverrides != nil && len(o.credentialOverrides.Tenant) > 0 {
+		return o.credentialOverrides.Tenant
+	}
+
+	return os.Getenv(TenantEnvVar)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"time"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	// ExecTenantEnvVar is set for the credential plugin to the tenant that the
+	// credential is requested for.
+	ExecTenantEnvVar = "VERIFY_EXEC_TENANT"
+
+	defaultExecTimeout = time.Minute
+)
+
+// ExecConfig configures a credential plugin. The command is run whenever a new token
+// is needed and prints an ExecCredential as JSON to stdout. This allows the token or
+// the client credentials to be retrieved from a secret manager instead of being saved.
+type ExecConfig struct {
+	// Command is the executable to run. It is looked up in the PATH if it is not a path.
+	Command string `json:"command" yaml:"command"`
+
+	// Args are the arguments passed to the command.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+
+	// Env are additional environment variables passed to the command. The command
+	// inherits the environment of verifyctl.
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+
+	// Timeout limits how long the command can run, such as "30s". It defaults to a minute.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// ExecCredential is the output of a credential plugin. The plugin either returns an
+// access token or the credentials of an API client that are used to get one.
+type ExecCredential struct {
+	// Token is the access token.
+	Token string `json:"token,omitempty"`
+
+	// Expiry is when the token expires, in RFC 3339 format.
+	Expiry time.Time `json:"expiry,omitempty"`
+
+	// ExpiresIn is the lifetime of the token in seconds. It is used if Expiry is not set.
+	// If neither is set, the token is not reused by later commands.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+
+	// ClientID is the client ID of the API client. It overrides the configured client ID.
+	ClientID string `json:"client_id,omitempty"`
+
+	// ClientSecret is the client secret of the API client.
+	ClientSecret string `json:"client_secret,omitempty"`
+
+	// PrivateKey is the private key of the API client for 'private_key_jwt', as a JWK or PEM.
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// refreshWithExec runs the credential plugin. A returned token is used as is. Client
+// credentials are used to get a token, but are never saved with the session.
+func (o *AuthConfig) refreshWithExec(ctx context.Context) error {
+	credential, err := o.Exec.Run(ctx, o.Tenant)
+	if err != nil {
+		return err
+	}
+
+	if len(credential.Token) > 0 {
+		o.Token = credential.Token
+		o.Entitlements = nil
+		o.Expiry = credential.Expiry
+		if o.Expiry.IsZero() {
+			// without an expiry, the token is only used for the current command
+			o.Expiry = time.Now().Add(time.Duration(credential.ExpiresIn) * time.Second).UTC()
+		}
+
+		return nil
+	}
+
+	clientConfig := &ClientConfig{}
+	if o.Client != nil {
+		*clientConfig = *o.Client
+	}
+
+	if len(credential.ClientID) > 0 {
+		clientConfig.ClientID = credential.ClientID
+	}
+
+	clientConfig.ClientSecret = credential.ClientSecret
+	if len(credential.PrivateKey) > 0 {
+		clientConfig.ClientAuthType = PrivateKeyJWTAuthType
+		clientConfig.PrivateKey = credential.PrivateKey
+	}
+
+	if len(clientConfig.ClientID) == 0 {
+		return errorsx.G11NError("The credential plugin did not return a token or a client ID.")
+	}
+
+	client, err := clientConfig.ConvertToClient(o.Tenant, o.Scopes)
+	if err != nil {
+		return err
+	}
+
+	tokenResponse, err := TokenWithAPIClient(ctx, client, clientConfig.Parameters)
+	if err != nil {
+		return err
+	}
+
+	o.SetToken(tokenResponse)
+	return nil
+}
+
+// Run executes the credential plugin and parses the credential it prints.
+func (c *ExecConfig) Run(ctx context.Context, tenant string) (*ExecCredential, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	if len(c.Command) == 0 {
+		return nil, errorsx.G11NError("'exec.command' is required.")
+	}
+
+	timeout := defaultExecTimeout
+	if len(c.Timeout) > 0 {
+		d, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, errorsx.G11NError("invalid 'exec.timeout'; err=%v", err)
+		}
+
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Command, c.Args...)
+	cmd.Env = append(os.Environ(), ExecTenantEnvVar+"="+tenant)
+	for k, v := range c.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	// the plugin may prompt the user, so it shares the terminal
+	stdout := &bytes.Buffer{}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		vc.Logger.Errorf("credential plugin failed; command=%s, err=%v", c.Command, err)
+		return nil, errorsx.G11NError("The credential plugin '%s' failed; err=%v", c.Command, err)
+	}
+
+	credential := &ExecCredential{}
+	if err := json.Unmarshal(stdout.Bytes(), credential); err != nil {
+		vc.Logger.Errorf("unable to parse the output of the credential plugin; command=%s, err=%v", c.Command, err)
+		return nil, errorsx.G11NError("The credential plugin '%s' did not print a valid JSON credential.", c.Command)
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// NetworkConfig holds the network settings used to reach the tenant. They apply to
+// every request made to the tenant, including token requests.
+type NetworkConfig struct {
+	// Proxy is the URL of the HTTP or HTTPS proxy. By default, the proxy is taken
+	// from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	Proxy string `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+
+	// CAFile is the path to a PEM bundle of additional trusted CA certificates.
+	CAFile string `json:"caFile,omitempty" yaml:"caFile,omitempty"`
+
+	// ClientCert and ClientKey are the paths to the PEM certificate and key used
+	// for mutual TLS.
+	ClientCert string `json:"clientCert,omitempty" yaml:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty" yaml:"clientKey,omitempty"`
+
+	// TLSMinVersion is the minimum TLS version, either "1.2" or "1.3".
+	TLSMinVersion string `json:"tlsMinVersion,omitempty" yaml:"tlsMinVersion,omitempty"`
+
+	// Timeout limits the time taken by each request, such as "30s".
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
+// ApplyNetwork routes the requests to the tenant to the server of the session, through
+// a transport with the network settings of the session. This also covers requests
+// to the tenant made by the SDK and OAuth 2.0 clients.
+func (o *AuthConfig) ApplyNetwork() error {
+	if err := xhttp.RegisterServer(o.Tenant, o.Server); err != nil {
+		return err
+	}
+
+	return o.Network.Apply(o.Tenant)
+}
+
+// TenantURL returns the URL that the tenant APIs are relative to. It has no trailing
+// slash. Requests to it are sent to the server of the session, if one is set, by the
+// transport set up by ApplyNetwork, as are those of the SDK clients.
+func (o *AuthConfig) TenantURL() string {
+	return "https://" + o.Tenant
+}
+
+// ServerURL returns the URL that requests to the tenant are sent to, once ApplyNetwork
+// has been called. It is the server of the session, if one is set.
+func (o *AuthConfig) ServerURL() string {
+	u, err := url.Parse(o.TenantURL())
+	if err != nil {
+		return o.TenantURL()
+	}
+
+	return strings.TrimSuffix(xhttp.ResolveURL(u).String(), "/")
+}
+
+// Apply routes the requests to the host through a transport with the network
+// settings. If there are no settings, the standard transport is used.
+func (c *NetworkConfig) Apply(host string) error {
+	if c == nil {
+		xhttp.RegisterTransport(host, nil)
+		return nil
+	}
+
+	opts := &xhttp.TransportOptions{
+		ProxyURL:       c.Proxy,
+		CAFile:         c.CAFile,
+		ClientCertFile: c.ClientCert,
+		ClientKeyFile:  c.ClientKey,
+		TLSMinVersion:  c.TLSMinVersion,
+	}
+
+	if len(c.Timeout) > 0 {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return errorsx.G11NError("invalid 'network.timeout'; err=%v", err)
+		}
+
+		opts.Timeout = timeout
+	}
+
+	transport, err := xhttp.NewTransport(opts)
+	if err != nil {
+		return err
+	}
+
+	xhttp.RegisterTransport(host, transport)
+	return nil
+}
+
+// WithAbsolutePaths returns a copy of the settings with the file paths made absolute,
+// so that they can be resolved from any working directory.
+func (c *NetworkConfig) WithAbsolutePaths() *NetworkConfig {
+	if c == nil {
+		return nil
+	}
+
+	n := *c
+	for _, path := range []*string{&n.CAFile, &n.ClientCert, &n.ClientKey} {
+		if len(*path) == 0 {
+			continue
+		}
+
+		if absPath, err := filepath.Abs(*path); err == nil {
+			*path = absPath
+		}
+	}
+
+	return &n
+}
@@ -0,0 +1,372 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/google/uuid"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionTTL  = 30 * time.Minute
+)
+
+// PrivateKeyOptions are optional properties applied to the private key used for
+// 'private_key_jwt' client authentication.
+type PrivateKeyOptions struct {
+	// Certificate is the PEM encoded certificate of the key, or '@' followed by the
+	// path to the file. The certificate may also be included in the key file.
+	Certificate string
+
+	// Algorithm is the signing algorithm. It defaults to RS256 for RSA keys, the
+	// ECDSA algorithm that matches the curve for EC keys and EdDSA for Ed25519 keys.
+	Algorithm string
+
+	// KeyID overrides the key ID. It defaults to the SHA-1 thumbprint of the
+	// certificate, if one is provided, or the RFC 7638 thumbprint of the key.
+	KeyID string
+}
+
+// LoadPrivateKey parses a private key provided as a serialized JWK or as PEM. PKCS#1,
+// PKCS#8 and SEC 1 (EC) PEM keys are supported. If the value is prefixed with '@',
+// the rest of the value is treated as the path to the file with the key.
+func LoadPrivateKey(raw string, opts *PrivateKeyOptions) (*jose.JSONWebKey, error) {
+	if opts == nil {
+		opts = &PrivateKeyOptions{}
+	}
+
+	b, err := readValueOrFile(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwk *jose.JSONWebKey
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "-----BEGIN") {
+		jwk, err = parsePEMPrivateKey(b)
+	} else {
+		jwk = &jose.JSONWebKey{}
+		err = json.Unmarshal(b, jwk)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Certificate) > 0 {
+		cb, err := readValueOrFile(opts.Certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		certs, err := parsePEMCertificates(cb)
+		if err != nil {
+			return nil, err
+		}
+
+		jwk.Certificates = certs
+	}
+
+	if err := completeJWK(jwk, opts); err != nil {
+		return nil, err
+	}
+
+	return jwk, nil
+}
+
+// NewPrivateKeyJWT returns the client authentication for 'private_key_jwt'. The
+// 'x5t' header is added to the client assertion when the key has a certificate.
+func NewPrivateKeyJWT(tenant string, clientID string, jwk *jose.JSONWebKey) oidc.ClientAuth {
+	if len(jwk.CertificateThumbprintSHA1) == 0 {
+		return &oidc.PrivateKeyJWT{
+			Tenant:        tenant,
+			ClientID:      clientID,
+			PrivateKeyJWK: jwk,
+		}
+	}
+
+	return &certificatePrivateKeyJWT{
+		tenant:   tenant,
+		clientID: clientID,
+		jwk:      jwk,
+	}
+}
+
+// PublicJWK returns the public key to register on the API client.
+func PublicJWK(jwk *jose.JSONWebKey) jose.JSONWebKey {
+	public := jwk.Public()
+	public.Use = "sig"
+	return public
+}
+
+// DefaultAlgorithm returns the signing algorithm used for the key when none is set.
+func DefaultAlgorithm(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return string(jose.RS256), nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return string(jose.ES256), nil
+		case elliptic.P384():
+			return string(jose.ES384), nil
+		case elliptic.P521():
+			return string(jose.ES512), nil
+		}
+	case ed25519.PublicKey:
+		return string(jose.EdDSA), nil
+	}
+
+	return "", errorsx.G11NError("unsupported key type '%T'.", key)
+}
+
+// certificatePrivateKeyJWT signs the client assertion like oidc.PrivateKeyJWT and
+// adds the certificate thumbprint to the header.
+type certificatePrivateKeyJWT struct {
+	tenant   string
+	clientID string
+	jwk      *jose.JSONWebKey
+}
+
+func (c *certificatePrivateKeyJWT) GetParameters() (url.Values, error) {
+	now := time.Now().UTC()
+	claims := map[string]any{
+		"iss": c.clientID,
+		"sub": c.clientID,
+		"aud": []string{
+			fmt.Sprintf("https://%s/oauth2", c.tenant),
+			fmt.Sprintf("https://%s/oauth2/token", c.tenant),
+		},
+		"exp": now.Add(clientAssertionTTL).Unix(),
+		"iat": now.Unix(),
+		"jti": uuid.NewString(),
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.SignatureAlgorithm(c.jwk.Algorithm),
+		Key:       c.jwk,
+	}, &jose.SignerOptions{
+		ExtraHeaders: map[jose.HeaderKey]any{
+			jose.HeaderType:       "JWT",
+			jose.HeaderKey("x5t"): base64.RawURLEncoding.EncodeToString(c.jwk.CertificateThumbprintSHA1),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, errorsx.G11NError("marshaling claims failed; err= %v", err)
+	}
+
+	o, err := signer.Sign(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := o.CompactSerialize()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := url.Values{}
+	ret.Add("client_id", c.clientID)
+	ret.Add("client_assertion_type", clientAssertionType)
+	ret.Add("client_assertion", token)
+	return ret, nil
+}
+
+func readValueOrFile(raw string) ([]byte, error) {
+	path, ok := strings.CutPrefix(raw, "@")
+	if !ok {
+		return []byte(raw), nil
+	}
+
+	return os.ReadFile(path)
+}
+
+// parsePEMPrivateKey reads the first private key in the PEM data. Certificates in the
+// same data are attached to the key.
+func parsePEMPrivateKey(b []byte) (*jose.JSONWebKey, error) {
+	jwk := &jose.JSONWebKey{}
+	for block, rest := pem.Decode(b); block != nil; block, rest = pem.Decode(rest) {
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errorsx.G11NError("invalid certificate; err=%v", err)
+			}
+
+			jwk.Certificates = append(jwk.Certificates, cert)
+		case "RSA PRIVATE KEY", "PRIVATE KEY", "EC PRIVATE KEY":
+			if jwk.Key != nil {
+				continue
+			}
+
+			key, err := parsePrivateKeyBlock(block)
+			if err != nil {
+				return nil, err
+			}
+
+			jwk.Key = key
+		}
+	}
+
+	if jwk.Key == nil {
+		return nil, errorsx.G11NError("no private key found in the PEM data.")
+	}
+
+	return jwk, nil
+}
+
+func parsePrivateKeyBlock(block *pem.Block) (crypto.PrivateKey, error) {
+	if x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck // only used to report a clear error
+		return nil, errorsx.G11NError("encrypted PEM keys are not supported.")
+	}
+
+	var key crypto.PrivateKey
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, errorsx.G11NError("invalid private key; err=%v", err)
+	}
+
+	// go-jose expects the value type for Ed25519 keys
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		key = *k
+	}
+
+	return key, nil
+}
+
+func parsePEMCertificates(b []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(b); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errorsx.G11NError("invalid certificate; err=%v", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errorsx.G11NError("no certificate found in the PEM data.")
+	}
+
+	return certs, nil
+}
+
+// completeJWK fills in the algorithm, key ID and certificate thumbprints.
+func completeJWK(jwk *jose.JSONWebKey, opts *PrivateKeyOptions) error {
+	if jwk.IsPublic() {
+		return errorsx.G11NError("the key is not a private key.")
+	}
+
+	public := jwk.Public()
+	if len(jwk.Certificates) > 0 {
+		cert := jwk.Certificates[0]
+		if !publicKeysEqual(cert.PublicKey, public.Key) {
+			return errorsx.G11NError("the certificate does not match the private key.")
+		}
+
+		sha1Sum := sha1.Sum(cert.Raw)
+		sha256Sum := sha256.Sum256(cert.Raw)
+		jwk.CertificateThumbprintSHA1 = sha1Sum[:]
+		jwk.CertificateThumbprintSHA256 = sha256Sum[:]
+	}
+
+	if len(opts.Algorithm) > 0 {
+		if err := checkAlgorithm(public.Key, opts.Algorithm); err != nil {
+			return err
+		}
+
+		jwk.Algorithm = opts.Algorithm
+	} else if len(jwk.Algorithm) == 0 {
+		alg, err := DefaultAlgorithm(public.Key)
+		if err != nil {
+			return err
+		}
+
+		jwk.Algorithm = alg
+	}
+
+	switch {
+	case len(opts.KeyID) > 0:
+		jwk.KeyID = opts.KeyID
+	case len(jwk.CertificateThumbprintSHA1) > 0:
+		jwk.KeyID = base64.RawURLEncoding.EncodeToString(jwk.CertificateThumbprintSHA1)
+	case len(jwk.KeyID) == 0:
+		thumbprint, err := public.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return err
+		}
+
+		jwk.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+	}
+
+	return nil
+}
+
+// checkAlgorithm verifies that the signing algorithm can be used with the key.
+func checkAlgorithm(key crypto.PublicKey, alg string) error {
+	var allowed []jose.SignatureAlgorithm
+	switch key.(type) {
+	case *rsa.PublicKey:
+		allowed = []jose.SignatureAlgorithm{jose.RS256, jose.RS384, jose.RS512, jose.PS256, jose.PS384, jose.PS512}
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		// the curve determines the algorithm
+		defaultAlg, err := DefaultAlgorithm(key)
+		if err != nil {
+			return err
+		}
+
+		allowed = []jose.SignatureAlgorithm{jose.SignatureAlgorithm(defaultAlg)}
+	default:
+		return errorsx.G11NError("unsupported key type '%T'.", key)
+	}
+
+	for _, a := range allowed {
+		if string(a) == alg {
+			return nil
+		}
+	}
+
+	return errorsx.G11NError("the algorithm '%s' cannot be used with the key. Supported algorithms: %v", alg, allowed)
+}
+
+func publicKeysEqual(a crypto.PublicKey, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
@@ -0,0 +1,349 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	cmdutil "github.com/ibm-verify/verifyctl/pkg/util/cmd"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+	"gopkg.in/yaml.v3"
+)
+
+// legacyAPIVersion is assumed for files that do not declare a version.
+const legacyAPIVersion = "1.0"
+
+// migration upgrades the raw document of a config file from one version to the next.
+type migration struct {
+	from    string
+	to      string
+	migrate func(doc map[string]interface{}) error
+}
+
+// migrations are applied in order until the file reaches the current version.
+var migrations = []migration{
+	{from: "1.0", to: "1.1", migrate: migrateContexts},
+}
+
+// InvalidConfigError is returned when the config file does not match the schema.
+type InvalidConfigError struct {
+	Path string
+	Err  error
+}
+
+func (e *InvalidConfigError) Error() string {
+	return fmt.Sprintf("The configuration file '%s' is invalid: %v\nUse 'verifyctl config validate' to check the file.", e.Path, e.Err)
+}
+
+func (e *InvalidConfigError) Unwrap() error {
+	return e.Err
+}
+
+// decodeConfig upgrades the file to the current version and decodes it. Fields that
+// are not part of the schema are rejected. If the file was upgraded, the original is
+// kept to be backed up when the upgraded file is written.
+func (o *CLIConfig) decodeConfig(configFile string, data []byte) error {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return &InvalidConfigError{Path: configFile, Err: err}
+	}
+
+	if len(doc) == 0 {
+		// an empty file
+		return nil
+	}
+
+	fileKind, _ := doc["kind"].(string)
+	if len(fileKind) > 0 && fileKind != kind {
+		return &InvalidConfigError{Path: configFile, Err: errorsx.G11NError("unsupported kind '%s'. Expected '%s'", fileKind, kind)}
+	}
+
+	version := legacyAPIVersion
+	if v, ok := doc["apiVersion"]; ok && v != nil {
+		version = fmt.Sprint(v)
+
+		// an unquoted version such as 1.0 is read as a number
+		if _, isNumber := v.(string); !isNumber && !strings.Contains(version, ".") {
+			version += ".0"
+		}
+	}
+
+	fileVersion := version
+	if err := migrate(doc, version); err != nil {
+		return &InvalidConfigError{Path: configFile, Err: err}
+	}
+
+	// files at the current version are decoded as is, so errors refer to their lines
+	migrated := data
+	if fileVersion != apiVersion {
+		b, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		migrated = b
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(migrated))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(o); err != nil {
+		return &InvalidConfigError{Path: configFile, Err: err}
+	}
+
+	if fileVersion == apiVersion {
+		o.Kind = kind
+		return nil
+	}
+
+	o.upgrade = &upgrade{
+		fromVersion: fileVersion,
+		original:    data,
+	}
+
+	return nil
+}
+
+// upgrade records the original content of a file that was upgraded when it was loaded.
+type upgrade struct {
+	fromVersion string
+	original    []byte
+}
+
+// backup keeps the original file with the version as a suffix, such as "config.1.0.bak".
+func (u *upgrade) backup(configFile string) error {
+	backupFile := fmt.Sprintf("%s.%s.bak", configFile, u.fromVersion)
+	if err := cmdutil.WritePrivateFile(backupFile, u.original); err != nil {
+		return errorsx.G11NError("unable to back up the configuration file before upgrading it; err=%v", err)
+	}
+
+	return nil
+}
+
+// migrate applies the migrations from the version to the current version.
+func migrate(doc map[string]interface{}, version string) error {
+	if compareVersions(version, apiVersion) > 0 {
+		return errorsx.G11NError("version '%s' is not supported. The file was written by a newer version of verifyctl that supports up to version '%s'", version, apiVersion)
+	}
+
+	for _, m := range migrations {
+		if m.from != version {
+			continue
+		}
+
+		if err := m.migrate(doc); err != nil {
+			return errorsx.G11NError("unable to upgrade from version '%s' to '%s'; err=%v", m.from, m.to, err)
+		}
+
+		version = m.to
+	}
+
+	if version != apiVersion {
+		return errorsx.G11NError("unsupported version '%s'", version)
+	}
+
+	doc["apiVersion"] = apiVersion
+	doc["kind"] = kind
+	return nil
+}
+
+// migrateContexts adds the contexts introduced in version 1.1. Each tenant gets a
+// context named after it and the current tenant becomes the current context.
+func migrateContexts(doc map[string]interface{}) error {
+	contexts, _ := doc["contexts"].([]interface{})
+	hasContext := map[string]string{}
+	for _, c := range contexts {
+		if m, ok := c.(map[string]interface{}); ok {
+			tenant := fmt.Sprint(m["tenant"])
+			if _, ok := hasContext[tenant]; !ok {
+				hasContext[tenant] = fmt.Sprint(m["name"])
+			}
+		}
+	}
+
+	auth, _ := doc["auth"].([]interface{})
+	for _, a := range auth {
+		m, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		tenant, _ := m["tenant"].(string)
+		if _, ok := hasContext[tenant]; ok || len(tenant) == 0 {
+			continue
+		}
+
+		contexts = append(contexts, map[string]interface{}{
+			"name":   tenant,
+			"tenant": tenant,
+		})
+		hasContext[tenant] = tenant
+	}
+
+	if len(contexts) > 0 {
+		doc["contexts"] = contexts
+	}
+
+	tenant, _ := doc["tenant"].(string)
+	if current, _ := doc["currentContext"].(string); len(current) == 0 && len(tenant) > 0 {
+		if name, ok := hasContext[tenant]; ok {
+			doc["currentContext"] = name
+		}
+	}
+
+	return nil
+}
+
+// compareVersions compares versions of the form "major.minor".
+func compareVersions(a string, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// Validate checks the consistency of the configuration and returns the problems found.
+func (o *CLIConfig) Validate() []string {
+	problems := []string{}
+	if o.Kind != kind {
+		problems = append(problems, fmt.Sprintf("'kind' is '%s'. Expected '%s'.", o.Kind, kind))
+	}
+
+	if o.APIVersion != apiVersion {
+		problems = append(problems, fmt.Sprintf("'apiVersion' is '%s'. Expected '%s'.", o.APIVersion, apiVersion))
+	}
+
+	tenants := map[string]bool{}
+	credentials := map[string]bool{}
+	for i, c := range o.Auth {
+		if len(c.Tenant) == 0 {
+			problems = append(problems, fmt.Sprintf("auth[%d]: 'tenant' is empty.", i))
+			continue
+		}
+
+		if credentials[c.credentialKey()] {
+			if len(c.Name) > 0 {
+				problems = append(problems, fmt.Sprintf("auth[%d]: the credential '%s' of the tenant '%s' is duplicated.", i, c.Name, c.Tenant))
+			} else {
+				problems = append(problems, fmt.Sprintf("auth[%d]: the tenant '%s' is duplicated.", i, c.Tenant))
+			}
+		}
+
+		tenants[c.Tenant] = true
+		credentials[c.credentialKey()] = true
+		for _, p := range c.validate() {
+			problems = append(problems, fmt.Sprintf("auth[%d] (%s): %s", i, c.Tenant, p))
+		}
+	}
+
+	names := map[string]bool{}
+	for i, c := range o.Contexts {
+		if len(c.Name) == 0 {
+			problems = append(problems, fmt.Sprintf("contexts[%d]: 'name' is empty.", i))
+		} else if names[c.Name] {
+			problems = append(problems, fmt.Sprintf("contexts[%d]: the name '%s' is duplicated.", i, c.Name))
+		}
+
+		names[c.Name] = true
+		if !tenants[c.Tenant] {
+			problems = append(problems, fmt.Sprintf("contexts[%d] (%s): there is no auth entry for the tenant '%s'.", i, c.Name, c.Tenant))
+		}
+	}
+
+	if len(o.CurrentTenant) > 0 && !tenants[o.CurrentTenant] {
+		problems = append(problems, fmt.Sprintf("'tenant': there is no auth entry for the current tenant '%s'.", o.CurrentTenant))
+	}
+
+	if len(o.CurrentContext) > 0 {
+		if c := o.GetContext(o.CurrentContext); c == nil {
+			problems = append(problems, fmt.Sprintf("'currentContext': the context '%s' does not exist.", o.CurrentContext))
+		} else if len(o.CurrentTenant) > 0 && c.Tenant != o.CurrentTenant {
+			problems = append(problems, fmt.Sprintf("'currentContext': the context '%s' is for the tenant '%s', but the current tenant is '%s'.", c.Name, c.Tenant, o.CurrentTenant))
+		}
+	}
+
+	if _, err := NewCredentialStore(o.CredentialStore); err != nil {
+		problems = append(problems, fmt.Sprintf("'credentialStore': %v", err))
+	}
+
+	return problems
+}
+
+// validate checks the settings of the session that cannot be checked by the schema.
+func (o *AuthConfig) validate() []string {
+	problems := []string{}
+	if len(o.Server) > 0 {
+		if _, err := xhttp.ParseServerURL(o.Server); err != nil {
+			problems = append(problems, fmt.Sprintf("'server': %v", err))
+		}
+	}
+
+	if o.Client != nil {
+		if len(o.Client.ClientID) == 0 {
+			problems = append(problems, "'client.clientId' is empty.")
+		}
+
+		if o.Client.ClientAuthType == PrivateKeyJWTAuthType && len(o.Client.PrivateKey) == 0 {
+			problems = append(problems, "'client.key' is required for 'private_key_jwt'.")
+		}
+	}
+
+	if o.Exec != nil {
+		if len(o.Exec.Command) == 0 {
+			problems = append(problems, "'exec.command' is empty.")
+		}
+
+		if _, err := time.ParseDuration(o.Exec.Timeout); len(o.Exec.Timeout) > 0 && err != nil {
+			problems = append(problems, fmt.Sprintf("'exec.timeout': %v", err))
+		}
+	}
+
+	if o.Network != nil {
+		if _, err := time.ParseDuration(o.Network.Timeout); len(o.Network.Timeout) > 0 && err != nil {
+			problems = append(problems, fmt.Sprintf("'network.timeout': %v", err))
+		}
+
+		switch o.Network.TLSMinVersion {
+		case "", "1.2", "1.3":
+		default:
+			problems = append(problems, fmt.Sprintf("'network.tlsMinVersion': unsupported value '%s'.", o.Network.TLSMinVersion))
+		}
+
+		paths := []struct {
+			name string
+			path string
+		}{
+			{"caFile", o.Network.CAFile},
+			{"clientCert", o.Network.ClientCert},
+			{"clientKey", o.Network.ClientKey},
+		}
+
+		for _, p := range paths {
+			if len(p.path) > 0 && !filepath.IsAbs(p.path) {
+				problems = append(problems, fmt.Sprintf("'network.%s': the path '%s' is not absolute.", p.name, p.path))
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+
+		wantContexts       []*ContextConfig
+		wantCurrentContext string
+		wantUpgradeFrom    string
+		wantErr            string
+	}{
+		{
+			name: "upgrades a file without a version",
+			data: `
+tenant: abc.verify.ibm.com
+auth:
+  - tenant: abc.verify.ibm.com
+    token: a
+  - tenant: xyz.verify.ibm.com
+    token: b
+`,
+			wantContexts: []*ContextConfig{
+				{Name: "abc.verify.ibm.com", Tenant: "abc.verify.ibm.com"},
+				{Name: "xyz.verify.ibm.com", Tenant: "xyz.verify.ibm.com"},
+			},
+			wantCurrentContext: "abc.verify.ibm.com",
+			wantUpgradeFrom:    "1.0",
+		},
+		{
+			name: "upgrades a file with an unquoted version",
+			data: `
+apiVersion: 1.0
+kind: Config
+tenant: abc.verify.ibm.com
+auth:
+  - tenant: abc.verify.ibm.com
+    token: a
+`,
+			wantContexts: []*ContextConfig{
+				{Name: "abc.verify.ibm.com", Tenant: "abc.verify.ibm.com"},
+			},
+			wantCurrentContext: "abc.verify.ibm.com",
+			wantUpgradeFrom:    "1.0",
+		},
+		{
+			name: "keeps the contexts of a version 1.0 file",
+			data: `
+apiVersion: "1.0"
+kind: Config
+tenant: abc.verify.ibm.com
+contexts:
+  - name: prod
+    tenant: abc.verify.ibm.com
+auth:
+  - tenant: abc.verify.ibm.com
+    token: a
+  - tenant: xyz.verify.ibm.com
+    token: b
+`,
+			wantContexts: []*ContextConfig{
+				{Name: "prod", Tenant: "abc.verify.ibm.com"},
+				{Name: "xyz.verify.ibm.com", Tenant: "xyz.verify.ibm.com"},
+			},
+			wantCurrentContext: "prod",
+			wantUpgradeFrom:    "1.0",
+		},
+		{
+			name: "decodes a current file as is",
+			data: `
+apiVersion: "1.1"
+kind: Config
+tenant: abc.verify.ibm.com
+currentContext: prod
+contexts:
+  - name: prod
+    tenant: abc.verify.ibm.com
+auth:
+  - tenant: abc.verify.ibm.com
+    token: a
+`,
+			wantContexts: []*ContextConfig{
+				{Name: "prod", Tenant: "abc.verify.ibm.com"},
+			},
+			wantCurrentContext: "prod",
+		},
+		{
+			name: "rejects a newer minor version",
+			data: `
+apiVersion: "1.2"
+kind: Config
+auth: []
+`,
+			wantErr: "version '1.2' is not supported",
+		},
+		{
+			name: "rejects a newer major version",
+			data: `
+apiVersion: "2.0"
+kind: Config
+auth: []
+`,
+			wantErr: "version '2.0' is not supported",
+		},
+		{
+			name: "rejects an unknown older version",
+			data: `
+apiVersion: "0.9"
+kind: Config
+auth: []
+`,
+			wantErr: "unsupported version '0.9'",
+		},
+		{
+			name: "rejects another kind",
+			data: `
+apiVersion: "1.1"
+kind: User
+`,
+			wantErr: "unsupported kind 'User'",
+		},
+		{
+			name: "rejects unknown fields",
+			data: `
+apiVersion: "1.1"
+kind: Config
+auth:
+  - tenant: abc.verify.ibm.com
+    tokn: a
+`,
+			wantErr: "field tokn not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCLIConfig()
+			err := c.decodeConfig("config", []byte(tt.data))
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeConfig() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("decodeConfig() error = %v", err)
+			}
+
+			if c.APIVersion != apiVersion || c.Kind != kind {
+				t.Errorf("decodeConfig() version = %s %s, want %s %s", c.APIVersion, c.Kind, apiVersion, kind)
+			}
+
+			if !reflect.DeepEqual(c.Contexts, tt.wantContexts) {
+				t.Errorf("decodeConfig() contexts = %v, want %v", c.Contexts, tt.wantContexts)
+			}
+
+			if c.CurrentContext != tt.wantCurrentContext {
+				t.Errorf("decodeConfig() current context = %q, want %q", c.CurrentContext, tt.wantCurrentContext)
+			}
+
+			upgradeFrom := ""
+			if c.upgrade != nil {
+				upgradeFrom = c.upgrade.fromVersion
+			}
+
+			if upgradeFrom != tt.wantUpgradeFrom {
+				t.Errorf("decodeConfig() upgraded from %q, want %q", upgradeFrom, tt.wantUpgradeFrom)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileUpgrade(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("VERIFY_HOME", configDir)
+
+	original := `
+tenant: abc.verify.ibm.com
+auth:
+  - tenant: abc.verify.ibm.com
+    token: a
+    isUser: true
+`
+	configFile := filepath.Join(configDir, fileName)
+	if err := os.WriteFile(configFile, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	upgraded, err := NewCLIConfig().LoadFromFile()
+	if err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+
+	backup, err := os.ReadFile(configFile + ".1.0.bak")
+	if err != nil || string(backup) != original {
+		t.Fatalf("the original file was not backed up: %q, err=%v", backup, err)
+	}
+
+	// the upgraded file is at the current version and loads to the same configuration
+	written := readConfigFile(t, configDir)
+	reloaded, err := NewCLIConfig().LoadFromFile()
+	if err != nil {
+		t.Fatalf("LoadFromFile() of the upgraded file error = %v", err)
+	}
+
+	if reloaded.upgrade != nil {
+		t.Errorf("LoadFromFile() upgraded the file again from %s", reloaded.upgrade.fromVersion)
+	}
+
+	upgraded.store, reloaded.store = nil, nil
+	upgraded.unlocked, reloaded.unlocked = false, false
+	if !reflect.DeepEqual(upgraded, reloaded) {
+		t.Errorf("LoadFromFile() = %+v, want %+v", reloaded, upgraded)
+	}
+
+	if got := readConfigFile(t, configDir); got != written {
+		t.Errorf("loading the upgraded file changed it:\n%s\nwant\n%s", got, written)
+	}
+
+	if problems := reloaded.Validate(); len(problems) > 0 {
+		t.Errorf("Validate() = %v", problems)
+	}
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	// expirySkew renews tokens slightly ahead of time so that a request
+	// does not fail while in flight.
+	expirySkew = time.Minute
+)
+
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// IsExpired returns true if the token has expired or is about to expire. Tokens
+// without a known expiry are never treated as expired.
+func (o *AuthConfig) IsExpired() bool {
+	return o.ExpiresWithin(expirySkew)
+}
+
+// ExpiresWithin returns true if the token expires before the duration has passed.
+// Tokens without a known expiry never expire.
+func (o *AuthConfig) ExpiresWithin(d time.Duration) bool {
+	if o.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(d).After(o.Expiry)
+}
+
+// SetToken updates the session with the token response.
+func (o *AuthConfig) SetToken(tokenResponse *oidc.TokenResponse) {
+	o.Token = tokenResponse.AccessToken
+	o.Entitlements = nil
+	if len(tokenResponse.RefreshToken) > 0 {
+		o.RefreshToken = tokenResponse.RefreshToken
+	}
+
+	o.Expiry = time.Time{}
+	if tokenResponse.ExpiresIn > 0 {
+		o.Expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second).UTC()
+	}
+
+	if len(tokenResponse.Scope) > 0 {
+		o.Scopes = strings.Fields(tokenResponse.Scope)
+	}
+}
+
+// Refresh renews the access token, or gets one for an ephemeral session. Sessions
+// with a credential plugin run the plugin, user sessions use the refresh token and
+// API clients run the client credentials grant.
+func (o *AuthConfig) Refresh(ctx context.Context) error {
+	if o.Exec != nil {
+		return o.refreshWithExec(ctx)
+	}
+
+	if o.Client == nil {
+		return errorsx.G11NError("The session has expired. Login again.")
+	}
+
+	client, err := o.Client.ConvertToClient(o.Tenant, o.Scopes)
+	if err != nil {
+		return err
+	}
+
+	// the secret of an API client is not saved unless the user opted in
+	if !o.User && !o.Client.HasSecret() {
+		return errorsx.G11NError("The session has expired. Login again.")
+	}
+
+	var tokenResponse *oidc.TokenResponse
+	if o.User {
+		if len(o.RefreshToken) == 0 {
+			return errorsx.G11NError("The session has expired. Login again.")
+		}
+
+		tokenResponse, err = o.refreshWithRefreshToken(ctx, client)
+	} else {
+		tokenResponse, err = TokenWithAPIClient(ctx, client, o.Client.Parameters)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	o.SetToken(tokenResponse)
+	return nil
+}
+
+// TokenWithAPIClient runs the client credentials grant. Unlike the SDK client, the
+// lifetime of the token is kept, so that the token can be renewed before it expires.
+func TokenWithAPIClient(ctx context.Context, client *oidc.Client, parameters url.Values) (*oidc.TokenResponse, error) {
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := params.Get("client_id")
+	params.Del("client_id")
+	clientSecret := params.Get("client_secret")
+	params.Del("client_secret")
+
+	for k := range parameters {
+		params.Add(k, parameters.Get(k))
+	}
+
+	oauthConfig := &clientcredentials.Config{
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+		TokenURL:       fmt.Sprintf("https://%s/oauth2/token", client.Tenant),
+		AuthStyle:      oauth2.AuthStyleInParams,
+		EndpointParams: params,
+		Scopes:         client.Scopes,
+	}
+
+	t, err := oauthConfig.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenResponse(t), nil
+}
+
+// TokenWithAuthCode exchanges the authorization code of the callback for a token. Unlike
+// the SDK client, the lifetime of the token is kept, so that the token can be renewed
+// before it expires.
+func TokenWithAuthCode(ctx context.Context, client *oidc.Client, authResponse *oidc.AuthorizeResponse, callbackParams url.Values) (*oidc.TokenResponse, error) {
+	if callbackParams.Get("state") != authResponse.State {
+		return nil, errorsx.G11NError("'state' does not match.")
+	}
+
+	if callbackParams.Get("error") != "" {
+		return nil, errorsx.G11NError("error: %s, description: %s", callbackParams.Get("error"), callbackParams.Get("error_description"))
+	}
+
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := params.Get("client_id")
+	params.Del("client_id")
+	clientSecret := params.Get("client_secret")
+	params.Del("client_secret")
+
+	oauthConfig := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  fmt.Sprintf("https://%s/oauth2/authorize", client.Tenant),
+			TokenURL: fmt.Sprintf("https://%s/oauth2/token", client.Tenant),
+		},
+		Scopes:      client.Scopes,
+		RedirectURL: client.RedirectURL,
+	}
+
+	opts := []oauth2.AuthCodeOption{}
+	for k := range params {
+		opts = append(opts, oauth2.SetAuthURLParam(k, params.Get(k)))
+	}
+
+	opts = append(opts, oauth2.VerifierOption(authResponse.PKCECodeVerifier))
+	t, err := oauthConfig.Exchange(ctx, callbackParams.Get("code"), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenResponse(t), nil
+}
+
+// newTokenResponse converts the token, with its lifetime taken from the expiry, as the
+// OAuth 2.0 client does not keep "expires_in".
+func newTokenResponse(t *oauth2.Token) *oidc.TokenResponse {
+	tokenResponse := oidc.NewTokenResponseWithOAuth2Token(t)
+	if !t.Expiry.IsZero() {
+		tokenResponse.ExpiresIn = int64(time.Until(t.Expiry).Seconds())
+	}
+
+	return tokenResponse
+}
+
+func (o *AuthConfig) refreshWithRefreshToken(ctx context.Context, client *oidc.Client) (*oidc.TokenResponse, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", o.RefreshToken)
+
+	u, _ := url.Parse(o.TenantURL() + "/oauth2/token")
+	headers := http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{"application/x-www-form-urlencoded"},
+	}
+
+	response, err := xhttp.NewDefaultClient().Post(ctx, u, headers, []byte(params.Encode()))
+	if err != nil {
+		vc.Logger.Errorf("unable to refresh the token; err=%v", err)
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		errorResponse := &tokenErrorResponse{}
+		_ = json.Unmarshal(response.Body, errorResponse)
+		vc.Logger.Errorf("unable to refresh the token; code=%d, error=%s, description=%s", response.StatusCode, errorResponse.Error, errorResponse.ErrorDescription)
+		return nil, errorsx.G11NError("The session could not be renewed. Login again.")
+	}
+
+	tokenResponse := &oidc.TokenResponse{}
+	if err := json.Unmarshal(response.Body, tokenResponse); err != nil {
+		return nil, errorsx.G11NError("unable to parse the token response")
+	}
+
+	return tokenResponse, nil
+}
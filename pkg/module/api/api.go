@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+)
+
+const (
+	scimContentType = "application/scim+json"
+	jsonContentType = "application/json"
+
+	// scimAPIPrefix is the path prefix of the SCIM APIs, such as Users and Groups.
+	scimAPIPrefix = "v2.0/"
+
+	// maxPages stops pagination when the tenant keeps returning pages.
+	maxPages = 1000
+)
+
+// Methods are the HTTP methods that can be sent.
+var Methods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// Request is a raw request to the tenant API. The path is relative to the base URL of
+// the tenant and may include a query string.
+type Request struct {
+	Method  string
+	Path    string
+	Headers http.Header
+	Body    []byte
+}
+
+type APIClient struct {
+	client xhttp.Clientx
+}
+
+func NewAPIClient() *APIClient {
+	return &APIClient{
+		client: xhttp.NewDefaultClient(),
+	}
+}
+
+// Do sends the request with the token of the session.
+func (c *APIClient) Do(ctx context.Context, auth *config.AuthConfig, req *Request) (*xhttp.Response, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	u, err := ResolveURL(auth, req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := jsonContentType
+	if strings.HasPrefix(u.Path, "/"+scimAPIPrefix) {
+		contentType = scimContentType
+	}
+
+	headers := http.Header{
+		"Accept":        []string{contentType + ", " + jsonContentType},
+		"Authorization": []string{"Bearer " + auth.Token},
+	}
+
+	if len(req.Body) > 0 {
+		headers.Set("Content-Type", contentType)
+	}
+
+	// the headers of the request replace the defaults, with all their values
+	for k, v := range req.Headers {
+		headers[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+
+	var response *xhttp.Response
+	switch strings.ToUpper(req.Method) {
+	case http.MethodGet:
+		response, err = c.client.Get(ctx, u, headers)
+	case http.MethodPost:
+		response, err = c.client.Post(ctx, u, headers, req.Body)
+	case http.MethodPut:
+		response, err = c.client.Put(ctx, u, headers, req.Body)
+	case http.MethodPatch:
+		response, err = c.client.Patch(ctx, u, headers, req.Body)
+	case http.MethodDelete:
+		response, err = c.client.Delete(ctx, u, headers)
+	default:
+		return nil, errorsx.G11NError("unsupported method '%s'. Use one of: %s", req.Method, strings.Join(Methods, ", "))
+	}
+
+	if err != nil {
+		vc.Logger.Errorf("unable to send the request; method=%s, url=%s, err=%v", req.Method, u.String(), err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Paginate gets every page of a list and returns the items merged into the first page.
+// SCIM lists are followed using 'startIndex' and 'count'. Other lists are followed using
+// 'page' and 'limit', until a page has fewer items than the limit. Following stops when
+// a page has no items that were not on the previous pages, as servers that ignore the
+// parameters return the same page again.
+func (c *APIClient) Paginate(ctx context.Context, auth *config.AuthConfig, req *Request) (*xhttp.Response, map[string]interface{}, error) {
+	response, first, err := c.getPage(ctx, auth, req)
+	if err != nil || first == nil {
+		return response, nil, err
+	}
+
+	path, query, _ := strings.Cut(req.Path, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, nil, errorsx.G11NError("invalid query string; err=%v", err)
+	}
+
+	if _, ok := first["Resources"]; ok {
+		err = c.paginateSCIM(ctx, auth, req, path, params, first)
+	} else {
+		err = c.paginatePages(ctx, auth, req, path, params, first)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, first, nil
+}
+
+func (c *APIClient) paginateSCIM(ctx context.Context, auth *config.AuthConfig, req *Request, path string, params url.Values, first map[string]interface{}) error {
+	items, _ := first["Resources"].([]interface{})
+	seen := map[string]bool{}
+	newItems(seen, items)
+	total := intValue(first["totalResults"])
+	startIndex := intValue(first["startIndex"])
+	if startIndex == 0 {
+		startIndex = 1
+	}
+
+	for pages := 1; pages < maxPages; pages++ {
+		startIndex += len(items)
+		if len(items) == 0 || startIndex > total {
+			break
+		}
+
+		params.Set("startIndex", strconv.Itoa(startIndex))
+		response, page, err := c.getPage(ctx, auth, &Request{Method: req.Method, Path: path + "?" + params.Encode(), Headers: req.Headers})
+		if err != nil {
+			return err
+		}
+
+		if page == nil {
+			return pageError(response, "startIndex="+strconv.Itoa(startIndex))
+		}
+
+		items, _ = page["Resources"].([]interface{})
+		if items = newItems(seen, items); len(items) == 0 {
+			break
+		}
+
+		all, _ := first["Resources"].([]interface{})
+		first["Resources"] = append(all, items...)
+	}
+
+	all, _ := first["Resources"].([]interface{})
+	first["itemsPerPage"] = len(all)
+	return nil
+}
+
+func (c *APIClient) paginatePages(ctx context.Context, auth *config.AuthConfig, req *Request, path string, params url.Values, first map[string]interface{}) error {
+	field, items, err := itemsField(first)
+	if err != nil {
+		return err
+	}
+
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	if limit == 0 {
+		limit = intValue(first["limit"])
+	}
+
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page == 0 {
+		page = 1
+	}
+
+	all := items
+	seen := map[string]bool{}
+	newItems(seen, items)
+	for pages := 1; pages < maxPages; pages++ {
+		if len(items) == 0 || (limit > 0 && len(items) < limit) {
+			break
+		}
+
+		page++
+		params.Set("page", strconv.Itoa(page))
+		if limit > 0 {
+			params.Set("limit", strconv.Itoa(limit))
+		}
+
+		response, next, err := c.getPage(ctx, auth, &Request{Method: req.Method, Path: path + "?" + params.Encode(), Headers: req.Headers})
+		if err != nil {
+			return err
+		}
+
+		if next == nil {
+			return pageError(response, "page="+strconv.Itoa(page))
+		}
+
+		pageItems, _ := next[field].([]interface{})
+		if items = newItems(seen, pageItems); len(items) == 0 {
+			break
+		}
+
+		all = append(all, items...)
+		if len(items) < len(pageItems) {
+			// the page overlaps the previous ones, so the server does not follow 'page'
+			break
+		}
+	}
+
+	first[field] = all
+	if _, ok := first["count"]; ok {
+		first["count"] = len(all)
+	}
+
+	return nil
+}
+
+// getPage returns the page as an object. The response is returned without the page if
+// the request failed, so that the caller can show the error.
+func (c *APIClient) getPage(ctx context.Context, auth *config.AuthConfig, req *Request) (*xhttp.Response, map[string]interface{}, error) {
+	response, err := c.Do(ctx, auth, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response, nil, nil
+	}
+
+	page := map[string]interface{}{}
+	if err := json.Unmarshal(response.Body, &page); err != nil {
+		return nil, nil, errorsx.G11NError("the response is not a JSON object and cannot be paginated")
+	}
+
+	return response, page, nil
+}
+
+// newItems returns the items that are not in the seen items, and adds them.
+func newItems(seen map[string]bool, items []interface{}) []interface{} {
+	result := []interface{}{}
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			result = append(result, item)
+			continue
+		}
+
+		if !seen[string(b)] {
+			seen[string(b)] = true
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func pageError(response *xhttp.Response, position string) error {
+	return errorsx.G11NError("unable to get the page at %s; code=%d, body=%s", position, response.StatusCode, string(response.Body))
+}
+
+// itemsField finds the array of items in a page, which is the only array at the top level.
+func itemsField(page map[string]interface{}) (string, []interface{}, error) {
+	field := ""
+	var items []interface{}
+	for k, v := range page {
+		a, ok := v.([]interface{})
+		if !ok {
+			continue
+		}
+
+		if len(field) > 0 {
+			return "", nil, errorsx.G11NError("the response has more than one list, '%s' and '%s', and cannot be paginated", field, k)
+		}
+
+		field, items = k, a
+	}
+
+	if len(field) == 0 {
+		return "", nil, errorsx.G11NError("the response does not have a list and cannot be paginated")
+	}
+
+	return field, items, nil
+}
+
+// ResolveURL returns the URL of the path on the tenant of the session.
+func ResolveURL(auth *config.AuthConfig, path string) (*url.URL, error) {
+	if strings.Contains(path, "://") {
+		return nil, errorsx.G11NError("the path '%s' must be relative to the tenant, such as '/v2.0/Users'", path)
+	}
+
+	u, err := url.Parse(auth.TenantURL() + "/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, errorsx.G11NError("invalid path '%s'; err=%v", path, err)
+	}
+
+	return u, nil
+}
+
+func intValue(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+
+	return 0
+}
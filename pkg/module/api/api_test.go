@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	"github.com/ibm-verify/verify-sdk-go/x/logx"
+)
+
+func TestPaginatePages(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// pages are the items of each page. Servers that ignore 'page' always return
+		// the first one.
+		pages      [][]interface{}
+		ignorePage bool
+
+		wantItems    int
+		wantRequests int
+	}{
+		{
+			name:         "follows pages until one is short",
+			pages:        [][]interface{}{{1.0, 2.0}, {3.0, 4.0}, {5.0}},
+			wantItems:    5,
+			wantRequests: 3,
+		},
+		{
+			name:         "stops after an empty page",
+			pages:        [][]interface{}{{1.0, 2.0}, {3.0, 4.0}, {}},
+			wantItems:    4,
+			wantRequests: 3,
+		},
+		{
+			name:         "stops when the page is repeated",
+			pages:        [][]interface{}{{1.0, 2.0}},
+			ignorePage:   true,
+			wantItems:    2,
+			wantRequests: 2,
+		},
+		{
+			name:         "stops when a page overlaps the previous ones",
+			pages:        [][]interface{}{{1.0, 2.0}, {2.0, 3.0}, {4.0, 5.0}},
+			wantItems:    3,
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				if page == 0 || tt.ignorePage {
+					page = 1
+				}
+
+				items := []interface{}{}
+				if page <= len(tt.pages) {
+					items = tt.pages[page-1]
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": items, "limit": 2})
+			}))
+			defer server.Close()
+
+			ctx, auth := newTestSession(t, server.URL)
+			_, first, err := NewAPIClient().Paginate(ctx, auth, &Request{Method: http.MethodGet, Path: "/v1.0/items"})
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+
+			items, _ := first["items"].([]interface{})
+			if len(items) != tt.wantItems {
+				t.Errorf("Paginate() returned %d items, want %d: %v", len(items), tt.wantItems, items)
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("Paginate() sent %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestDoHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    http.Header
+	}{
+		{
+			name:    "sends every value of a header",
+			headers: http.Header{"X-Trace": {"a", "b"}},
+			want:    http.Header{"X-Trace": {"a", "b"}},
+		},
+		{
+			name:    "replaces a default header",
+			headers: http.Header{"accept": {"text/csv"}},
+			want:    http.Header{"Accept": {"text/csv"}},
+		},
+		{
+			name:    "sends a header without values as none",
+			headers: http.Header{"X-Empty": {}},
+			want:    http.Header{"X-Empty": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			ctx, auth := newTestSession(t, server.URL)
+			if _, err := NewAPIClient().Do(ctx, auth, &Request{Method: http.MethodGet, Path: "/v1.0/items", Headers: tt.headers}); err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+
+			for k, want := range tt.want {
+				if !slices.Equal(got.Values(k), want) {
+					t.Errorf("Do() sent %s = %v, want %v", k, got.Values(k), want)
+				}
+			}
+		})
+	}
+}
+
+// newTestSession returns a session for a tenant whose requests are sent to the server.
+func newTestSession(t *testing.T, server string) (context.Context, *config.AuthConfig) {
+	t.Helper()
+	tenant := "paginate.test"
+	if err := xhttp.RegisterServer(tenant, server); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = xhttp.RegisterServer(tenant, "")
+	})
+
+	ctx, _ := contextx.NewContextWithVerifyContext(context.Background(), logx.NewLoggerWithWriter("test", slog.LevelError, io.Discard))
+	return ctx, &config.AuthConfig{Tenant: tenant, Token: "token"}
+}
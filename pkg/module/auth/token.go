@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module"
+	xhttp "github.com/ibm-verify/verifyctl/pkg/util/http"
+
+	oidc "github.com/ibm-verify/verify-sdk-go/pkg/auth"
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	apiIntrospect = "oauth2/introspect"
+	apiUserinfo   = "oauth2/userinfo"
+	apiRevoke     = "oauth2/revoke"
+)
+
+type TokenClient struct {
+	client xhttp.Clientx
+}
+
+func NewTokenClient() *TokenClient {
+	return &TokenClient{
+		client: xhttp.NewDefaultClient(),
+	}
+}
+
+// Introspect calls the tenant introspection endpoint for the session token. The
+// session must have the client used to log in, as the endpoint requires client
+// authentication.
+func (c *TokenClient) Introspect(ctx context.Context, auth *config.AuthConfig) (map[string]interface{}, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	if auth.Client == nil {
+		return nil, errorsx.G11NError("The session does not include the client details needed to introspect the token. Login again.")
+	}
+
+	client, err := auth.Client.ConvertToClient(auth.Tenant, auth.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	params.Set("token", auth.Token)
+	params.Set("token_type_hint", "access_token")
+
+	u, _ := url.Parse(auth.TenantURL() + "/" + apiIntrospect)
+	headers := http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{"application/x-www-form-urlencoded"},
+	}
+
+	response, err := c.client.Post(ctx, u, headers, []byte(params.Encode()))
+	if err != nil {
+		vc.Logger.Errorf("unable to introspect the token; err=%s", err.Error())
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		if err := module.HandleCommonErrorsX(ctx, response, "unable to introspect the token"); err != nil {
+			vc.Logger.Errorf("unable to introspect the token; err=%s", err.Error())
+			return nil, err
+		}
+
+		vc.Logger.Errorf("unable to introspect the token; code=%d, body=%s", response.StatusCode, string(response.Body))
+		return nil, errorsx.G11NError("unable to introspect the token")
+	}
+
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(response.Body, &claims); err != nil {
+		return nil, errorsx.G11NError("unable to introspect the token")
+	}
+
+	return claims, nil
+}
+
+// Revoke invalidates the token on the tenant of the session. The client must be the
+// one that the token was issued to.
+func (c *TokenClient) Revoke(ctx context.Context, auth *config.AuthConfig, client *oidc.Client, token string, tokenTypeHint string) error {
+	vc := contextx.GetVerifyContext(ctx)
+	params, err := client.ClientAuth.GetParameters()
+	if err != nil {
+		return err
+	}
+
+	params.Set("token", token)
+	if len(tokenTypeHint) > 0 {
+		params.Set("token_type_hint", tokenTypeHint)
+	}
+
+	u, _ := url.Parse(auth.TenantURL() + "/" + apiRevoke)
+	headers := http.Header{
+		"Accept":       []string{"application/json"},
+		"Content-Type": []string{"application/x-www-form-urlencoded"},
+	}
+
+	response, err := c.client.Post(ctx, u, headers, []byte(params.Encode()))
+	if err != nil {
+		vc.Logger.Errorf("unable to revoke the token; err=%s", err.Error())
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		if err := module.HandleCommonErrorsX(ctx, response, "unable to revoke the token"); err != nil {
+			vc.Logger.Errorf("unable to revoke the token; err=%s", err.Error())
+			return err
+		}
+
+		vc.Logger.Errorf("unable to revoke the token; code=%d, body=%s", response.StatusCode, string(response.Body))
+		return errorsx.G11NError("unable to revoke the token")
+	}
+
+	return nil
+}
+
+// GetUserinfo returns the claims of the user that the session token belongs to.
+func (c *TokenClient) GetUserinfo(ctx context.Context, auth *config.AuthConfig) (map[string]interface{}, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	u, _ := url.Parse(auth.TenantURL() + "/" + apiUserinfo)
+	headers := http.Header{
+		"Accept":        []string{"application/json"},
+		"Authorization": []string{"Bearer " + auth.Token},
+	}
+
+	response, err := c.client.Get(ctx, u, headers)
+	if err != nil {
+		vc.Logger.Errorf("unable to get the userinfo; err=%s", err.Error())
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		if err := module.HandleCommonErrorsX(ctx, response, "unable to get the userinfo"); err != nil {
+			vc.Logger.Errorf("unable to get the userinfo; err=%s", err.Error())
+			return nil, err
+		}
+
+		vc.Logger.Errorf("unable to get the userinfo; code=%d, body=%s", response.StatusCode, string(response.Body))
+		return nil, errorsx.G11NError("unable to get the userinfo")
+	}
+
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(response.Body, &claims); err != nil {
+		return nil, errorsx.G11NError("unable to get the userinfo")
+	}
+
+	return claims, nil
+}
+
+// ClaimAsStrings reads a claim that may either be a space-delimited string or
+// a list of strings.
+func ClaimAsStrings(claims map[string]interface{}, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		values := []string{}
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				values = append(values, str)
+			}
+		}
+
+		return values
+	}
+
+	return nil
+}
@@ -25,7 +25,7 @@ func NewIdentitySourceClient() *IdentitysourceClient {
 
 func (c *IdentitysourceClient) CreateIdentitysource(ctx context.Context, auth *config.AuthConfig, identitysource *IdentitySource) (string, error) {
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	defaultErr := errorsx.G11NError("unable to create identitysource")
 
 	body, err := json.Marshal(identitysource)
@@ -60,7 +60,7 @@ func (c *IdentitysourceClient) CreateIdentitysource(ctx context.Context, auth *c
 
 func (c *IdentitysourceClient) GetIdentitysource(ctx context.Context, auth *config.AuthConfig, identitysourceName string) (*IdentitySource, string, error) {
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	id, err := c.getIdentitysourceId(ctx, auth, identitysourceName)
 	if err != nil {
 		vc.Logger.Errorf("unable to get the group ID; err=%s", err.Error())
@@ -98,7 +98,7 @@ func (c *IdentitysourceClient) GetIdentitysource(ctx context.Context, auth *conf
 func (c *IdentitysourceClient) GetIdentitysources(ctx context.Context, auth *config.AuthConfig, sort string, count string) (*IdentitySourceList, string, error) {
 
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	params := &openapi.GetInstancesV2Params{}
 	if len(sort) > 0 {
 		params.Sort = &sort
@@ -139,7 +139,7 @@ func (c *IdentitysourceClient) GetIdentitysources(ctx context.Context, auth *con
 
 func (c *IdentitysourceClient) DeleteIdentitysource(ctx context.Context, auth *config.AuthConfig, name string) error {
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	id, err := c.getIdentitysourceId(ctx, auth, name)
 	if err != nil {
 		vc.Logger.Errorf("unable to get the identitysource ID; err=%s", err.Error())
@@ -171,7 +171,7 @@ func (c *IdentitysourceClient) DeleteIdentitysource(ctx context.Context, auth *c
 
 func (c *IdentitysourceClient) UpdateIdentitysource(ctx context.Context, auth *config.AuthConfig, identitysource *IdentitySource) error {
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	defaultErr := errorsx.G11NError("unable to update identitysource")
 	id, err := c.getIdentitysourceId(ctx, auth, identitysource.InstanceName)
 	fmt.Println(id)
@@ -206,7 +206,7 @@ func (c *IdentitysourceClient) UpdateIdentitysource(ctx context.Context, auth *c
 
 func (c *IdentitysourceClient) getIdentitysourceId(ctx context.Context, auth *config.AuthConfig, name string) (string, error) {
 	vc := contextx.GetVerifyContext(ctx)
-	client, _ := openapi.NewClientWithResponses(fmt.Sprintf("https://%s", auth.Tenant))
+	client, _ := openapi.NewClientWithResponses(auth.TenantURL())
 	search := fmt.Sprintf(`instanceName = "%s"`, name)
 	params := &openapi.GetInstancesV2Params{
 		Search: &search,
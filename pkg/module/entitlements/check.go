@@ -0,0 +1,163 @@
+package entitlements
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/auth"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// Granted returns the entitlements granted to the session token. The result of the
+// introspection is cached with the session until the token changes. A nil list means
+// that the entitlements could not be determined.
+func Granted(ctx context.Context, cliConfig *config.CLIConfig, authConfig *config.AuthConfig) ([]string, error) {
+	if authConfig.Entitlements != nil {
+		return authConfig.Entitlements, nil
+	}
+
+	claims, err := auth.NewTokenClient().Introspect(ctx, authConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if active, _ := claims["active"].(bool); !active {
+		return nil, errorsx.G11NError("The session is no longer active. Login again.")
+	}
+
+	granted := auth.ClaimAsStrings(claims, "entitlements")
+	if granted == nil {
+		return nil, nil
+	}
+
+	authConfig.Entitlements = granted
+	if err := cliConfig.UpdateAuth(authConfig); err != nil {
+		return nil, err
+	}
+
+	return granted, nil
+}
+
+// SetAuthToContext selects the credential of the current tenant that is used to
+// perform the verb on the resource, as described in SelectCredential, and sets it
+// to the context. An error is returned if the credential is not allowed.
+//
+// The check is skipped when the granted entitlements cannot be determined, such
+// as when the session has no client to introspect the token with.
+func SetAuthToContext(ctx context.Context, cliConfig *config.CLIConfig, verb string, resource string) (*config.AuthConfig, error) {
+	vc := contextx.GetVerifyContext(ctx)
+	rule, err := Lookup(verb, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	authConfig, err := SelectCredential(ctx, cliConfig, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := Granted(ctx, cliConfig, authConfig)
+	if err != nil {
+		vc.Logger.Warnf("unable to determine the granted entitlements; err=%v", err)
+		return authConfig, nil
+	}
+
+	if granted == nil || rule.Allows(granted) {
+		return authConfig, nil
+	}
+
+	if credentials, _ := cliConfig.CurrentCredentials(); len(credentials) > 1 {
+		return nil, errorsx.G11NError("None of the credentials for the tenant '%s' is allowed to %s %s. Configure one of the following entitlements on the application or API client and login again: %s",
+			authConfig.Tenant, rule.Verb, rule.Resource, rule.Describe())
+	}
+
+	return nil, errorsx.G11NError("The current session is not allowed to %s %s. Configure one of the following entitlements on the application or API client and login again: %s",
+		rule.Verb, rule.Resource, rule.Describe())
+}
+
+// SelectCredential returns the credential of the current tenant used for the rule and
+// sets it to the context. It is the least privileged credential that is granted the
+// entitlements of the rule, unless a credential was selected using the '--credential'
+// flag. The granted entitlements of each credential are cached, so the tokens are
+// only introspected the first time. If none of the credentials is known to be allowed,
+// one whose entitlements cannot be determined is preferred, as the tenant has the
+// final say.
+func SelectCredential(ctx context.Context, cliConfig *config.CLIConfig, rule *Rule) (*config.AuthConfig, error) {
+	credentials, err := cliConfig.CurrentCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	authConfig := credentials[0]
+	if len(credentials) > 1 {
+		authConfig = leastPrivileged(ctx, cliConfig, credentials, rule)
+	}
+
+	if err := cliConfig.ActivateAuth(ctx, authConfig); err != nil {
+		return nil, err
+	}
+
+	return authConfig, nil
+}
+
+func leastPrivileged(ctx context.Context, cliConfig *config.CLIConfig, credentials []*config.AuthConfig, rule *Rule) *config.AuthConfig {
+	vc := contextx.GetVerifyContext(ctx)
+
+	var selected, unknown *config.AuthConfig
+	var selectedManage, selectedTotal int
+	for _, c := range credentials {
+		granted := c.Entitlements
+		if granted == nil {
+			var err error
+			if err = cliConfig.ActivateAuth(ctx, c); err == nil {
+				granted, err = Granted(ctx, cliConfig, c)
+			}
+
+			if err != nil {
+				vc.Logger.Warnf("unable to determine the granted entitlements; tenant=%s, credential=%s, err=%v", c.Tenant, c.Name, err)
+			}
+		}
+
+		if granted == nil {
+			if unknown == nil {
+				unknown = c
+			}
+
+			continue
+		}
+
+		if !rule.Allows(granted) {
+			continue
+		}
+
+		manage, total := privilege(granted)
+		if selected == nil || manage < selectedManage || (manage == selectedManage && total < selectedTotal) {
+			selected, selectedManage, selectedTotal = c, manage, total
+		}
+	}
+
+	switch {
+	case selected != nil:
+		return selected
+	case unknown != nil:
+		return unknown
+	}
+
+	return credentials[0]
+}
+
+// privilege ranks the granted entitlements by the number of entitlements that allow
+// changes and then by the total number.
+func privilege(granted []string) (int, int) {
+	manage := 0
+	for _, g := range granted {
+		if strings.HasPrefix(g, "manage") {
+			manage++
+		}
+	}
+
+	return manage, len(granted)
+}
@@ -0,0 +1,212 @@
+package entitlements
+
+import (
+	"fmt"
+	"strings"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// Verbs used by the commands.
+const (
+	VerbGet     = "get"
+	VerbCreate  = "create"
+	VerbReplace = "replace"
+	VerbDelete  = "delete"
+	VerbSet     = "set"
+)
+
+// Resources managed by the commands.
+const (
+	ResourceUsers           = "users"
+	ResourceGroups          = "groups"
+	ResourceAttributes      = "attributes"
+	ResourceThemes          = "themes"
+	ResourceAPIClients      = "apiclients"
+	ResourceIdentitySources = "identitysources"
+	ResourceAccessPolicies  = "accesspolicies"
+	ResourceLogs            = "logs"
+)
+
+// Entitlement IDs configured on applications and API clients on Verify.
+const (
+	ManageUserGroups         = "manageUserGroups"
+	ManageAllUserGroups      = "manageAllUserGroups"
+	ManageUserStandardGroups = "manageUserStandardGroups"
+	ReadUserGroups           = "readUserGroups"
+	ManageAttributes         = "manageAttributes"
+	ReadAttributes           = "readAttributes"
+	ManageTemplates          = "manageTemplates"
+	ReadTemplates            = "readTemplates"
+	ManageAPIClients         = "manageAPIClients"
+	ReadAPIClients           = "readAPIClients"
+	ManageIdentitySources    = "manageIdentitySources"
+	ReadIdentitySources      = "readIdentitySources"
+	ManageAccessPolicies     = "manageAccessPolicies"
+	ReadAccessPolicies       = "readAccessPolicies"
+	ReadTraceLogs            = "readTraceLogs"
+)
+
+var descriptions = map[string]string{
+	ManageUserGroups:         "Manage users and groups",
+	ManageAllUserGroups:      "Synchronize users and groups",
+	ManageUserStandardGroups: "Manage users and standard groups",
+	ReadUserGroups:           "Read users and groups",
+	ManageAttributes:         "Manage attributes",
+	ReadAttributes:           "Read attributes",
+	ManageTemplates:          "Manage templates and themes",
+	ReadTemplates:            "Read templates and themes",
+	ManageAPIClients:         "Manage API clients",
+	ReadAPIClients:           "Read API clients",
+	ManageIdentitySources:    "Manage identity sources",
+	ReadIdentitySources:      "Read identity sources",
+	ManageAccessPolicies:     "Manage access policies",
+	ReadAccessPolicies:       "Read access policies",
+	ReadTraceLogs:            "Read trace logs",
+}
+
+// resourceAliases maps the names accepted on the command line to the resource.
+var resourceAliases = map[string]string{
+	"user":           ResourceUsers,
+	"group":          ResourceGroups,
+	"attribute":      ResourceAttributes,
+	"theme":          ResourceThemes,
+	"apiclient":      ResourceAPIClients,
+	"identitysource": ResourceIdentitySources,
+	"accesspolicy":   ResourceAccessPolicies,
+	"log":            ResourceLogs,
+}
+
+// kindResources maps the resource kinds, without the type prefix, to the resource.
+var kindResources = map[string]string{
+	"User":           ResourceUsers,
+	"Group":          ResourceGroups,
+	"Attribute":      ResourceAttributes,
+	"Theme":          ResourceThemes,
+	"APIClient":      ResourceAPIClients,
+	"IdentitySource": ResourceIdentitySources,
+	"AccessPolicy":   ResourceAccessPolicies,
+}
+
+// verbAliases maps alternative verbs to the verb of the command.
+var verbAliases = map[string]string{
+	"list":   VerbGet,
+	"update": VerbReplace,
+}
+
+// Rule lists the entitlements that allow a verb on a resource. Any one of the
+// entitlements is sufficient.
+type Rule struct {
+	Verb     string   `json:"verb" yaml:"verb"`
+	Resource string   `json:"resource" yaml:"resource"`
+	AnyOf    []string `json:"anyOf" yaml:"anyOf"`
+}
+
+var (
+	readUsersAndGroups   = []string{ReadUserGroups, ManageUserGroups, ManageAllUserGroups, ManageUserStandardGroups}
+	manageUsersAndGroups = []string{ManageUserGroups, ManageAllUserGroups, ManageUserStandardGroups}
+)
+
+// rules is the registry of the entitlements required by each command.
+var rules = []*Rule{
+	{Verb: VerbGet, Resource: ResourceUsers, AnyOf: readUsersAndGroups},
+	{Verb: VerbCreate, Resource: ResourceUsers, AnyOf: manageUsersAndGroups},
+	{Verb: VerbReplace, Resource: ResourceUsers, AnyOf: manageUsersAndGroups},
+	{Verb: VerbDelete, Resource: ResourceUsers, AnyOf: manageUsersAndGroups},
+
+	{Verb: VerbGet, Resource: ResourceGroups, AnyOf: readUsersAndGroups},
+	{Verb: VerbCreate, Resource: ResourceGroups, AnyOf: manageUsersAndGroups},
+	{Verb: VerbReplace, Resource: ResourceGroups, AnyOf: manageUsersAndGroups},
+	{Verb: VerbDelete, Resource: ResourceGroups, AnyOf: manageUsersAndGroups},
+
+	{Verb: VerbGet, Resource: ResourceAttributes, AnyOf: []string{ReadAttributes, ManageAttributes}},
+	{Verb: VerbCreate, Resource: ResourceAttributes, AnyOf: []string{ManageAttributes}},
+	{Verb: VerbReplace, Resource: ResourceAttributes, AnyOf: []string{ManageAttributes}},
+
+	{Verb: VerbGet, Resource: ResourceThemes, AnyOf: []string{ReadTemplates, ManageTemplates}},
+	{Verb: VerbSet, Resource: ResourceThemes, AnyOf: []string{ManageTemplates}},
+
+	{Verb: VerbGet, Resource: ResourceAPIClients, AnyOf: []string{ReadAPIClients, ManageAPIClients}},
+	{Verb: VerbCreate, Resource: ResourceAPIClients, AnyOf: []string{ManageAPIClients}},
+	{Verb: VerbReplace, Resource: ResourceAPIClients, AnyOf: []string{ManageAPIClients}},
+	{Verb: VerbDelete, Resource: ResourceAPIClients, AnyOf: []string{ManageAPIClients}},
+
+	{Verb: VerbGet, Resource: ResourceIdentitySources, AnyOf: []string{ReadIdentitySources, ManageIdentitySources}},
+	{Verb: VerbCreate, Resource: ResourceIdentitySources, AnyOf: []string{ManageIdentitySources}},
+	{Verb: VerbReplace, Resource: ResourceIdentitySources, AnyOf: []string{ManageIdentitySources}},
+	{Verb: VerbDelete, Resource: ResourceIdentitySources, AnyOf: []string{ManageIdentitySources}},
+
+	{Verb: VerbGet, Resource: ResourceAccessPolicies, AnyOf: []string{ReadAccessPolicies, ManageAccessPolicies}},
+	{Verb: VerbCreate, Resource: ResourceAccessPolicies, AnyOf: []string{ManageAccessPolicies}},
+	{Verb: VerbReplace, Resource: ResourceAccessPolicies, AnyOf: []string{ManageAccessPolicies}},
+	{Verb: VerbDelete, Resource: ResourceAccessPolicies, AnyOf: []string{ManageAccessPolicies}},
+
+	{Verb: VerbGet, Resource: ResourceLogs, AnyOf: []string{ReadTraceLogs}},
+}
+
+// Rules returns all the rules in the registry.
+func Rules() []*Rule {
+	return rules
+}
+
+// Lookup returns the rule for the verb and resource. Singular resource names and
+// the verbs 'list' and 'update' are accepted.
+func Lookup(verb string, resource string) (*Rule, error) {
+	verb = strings.ToLower(verb)
+	if v, ok := verbAliases[verb]; ok {
+		verb = v
+	}
+
+	resource = strings.ToLower(resource)
+	if r, ok := resourceAliases[resource]; ok {
+		resource = r
+	}
+
+	for _, r := range rules {
+		if r.Verb == verb && r.Resource == resource {
+			return r, nil
+		}
+	}
+
+	return nil, errorsx.G11NError("unknown verb '%s' for resource '%s'.", verb, resource)
+}
+
+// ResourceForKind returns the resource for the kind of a resource file. The kind is
+// expected without the type prefix.
+func ResourceForKind(kind string) (string, bool) {
+	resource, ok := kindResources[kind]
+	return resource, ok
+}
+
+// Describe returns the entitlements for the verb and resource in a readable form.
+func Describe(verb string, resource string) string {
+	r, err := Lookup(verb, resource)
+	if err != nil {
+		return ""
+	}
+
+	return r.Describe()
+}
+
+// Describe returns the entitlements of the rule in a readable form.
+func (r *Rule) Describe() string {
+	items := make([]string, 0, len(r.AnyOf))
+	for _, id := range r.AnyOf {
+		items = append(items, fmt.Sprintf("%s (%s)", id, descriptions[id]))
+	}
+
+	return strings.Join(items, " or ")
+}
+
+// Allows returns true if any of the granted entitlements satisfies the rule.
+func (r *Rule) Allows(granted []string) bool {
+	for _, g := range granted {
+		for _, id := range r.AnyOf {
+			if g == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,177 @@
+package kinds
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// timestampFields are set by the tenant on resources of any kind.
+var timestampFields = []string{"created", "lastModified", "createdTime", "lastModifiedTime", "lastUpdated", "lastUpdatedTime"}
+
+// Change is a field of the desired data that differs from the live resource.
+type Change struct {
+	// Path is the path to the field, such as "emails[0].value".
+	Path string
+
+	// Live is the value on the tenant, or nil if the field is missing.
+	Live interface{}
+
+	// Desired is the value of the file.
+	Desired interface{}
+}
+
+// Normalize returns a copy of the data without the fields set by the tenant, such as
+// IDs, metadata and timestamps, without the fields that are not compared, the last
+// applied hash and empty values.
+func (k *Kind) Normalize(data map[string]interface{}) (map[string]interface{}, error) {
+	m, err := k.writable(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range slices.Concat(k.IgnoredFields, k.SecretFields) {
+		delete(m, field)
+	}
+
+	k.withoutLastApplied(m)
+	clean(m)
+	return m, nil
+}
+
+// Changes returns the fields of the resolved desired data that differ from the live
+// resource, sorted by their path.
+func (k *Kind) Changes(live map[string]interface{}, desired map[string]interface{}) []*Change {
+	fields := make([]string, 0, len(desired))
+	for field := range desired {
+		if !k.ignored(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+	result := []*Change{}
+	for _, field := range fields {
+		result = appendChanges(result, field, live[field], desired[field])
+	}
+
+	return result
+}
+
+// Trim returns the live resource limited to the fields of the desired data, so that
+// both can be printed side by side. Values that match are taken from the desired data.
+func (k *Kind) Trim(live map[string]interface{}, desired map[string]interface{}) map[string]interface{} {
+	trimmed, _ := trim(live, desired).(map[string]interface{})
+	return trimmed
+}
+
+func appendChanges(result []*Change, path string, live interface{}, desired interface{}) []*Change {
+	if contains(live, desired) {
+		return result
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		if l, ok := live.(map[string]interface{}); ok {
+			fields := make([]string, 0, len(d))
+			for field := range d {
+				fields = append(fields, field)
+			}
+
+			sort.Strings(fields)
+			for _, field := range fields {
+				result = appendChanges(result, path+"."+field, l[field], d[field])
+			}
+
+			return result
+		}
+	case []interface{}:
+		if l, ok := live.([]interface{}); ok && len(l) == len(d) {
+			for i := range d {
+				result = appendChanges(result, path+"["+strconv.Itoa(i)+"]", l[i], d[i])
+			}
+
+			return result
+		}
+	}
+
+	return append(result, &Change{Path: path, Live: trim(live, desired), Desired: desired})
+}
+
+func trim(live interface{}, desired interface{}) interface{} {
+	if contains(live, desired) {
+		return desired
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+
+		m := map[string]interface{}{}
+		for field, value := range d {
+			if liveValue, ok := l[field]; ok {
+				m[field] = trim(liveValue, value)
+			} else if contains(nil, value) {
+				m[field] = value
+			}
+		}
+
+		return m
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+
+		items := make([]interface{}, len(l))
+		for i := range l {
+			if i < len(d) {
+				items[i] = trim(l[i], d[i])
+			} else {
+				items[i] = l[i]
+			}
+		}
+
+		return items
+	}
+
+	return live
+}
+
+// clean removes the timestamps and the empty values, which match missing ones.
+func clean(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for field, fieldValue := range v {
+			if slices.Contains(timestampFields, field) {
+				delete(v, field)
+				continue
+			}
+
+			clean(fieldValue)
+			switch f := fieldValue.(type) {
+			case nil:
+				delete(v, field)
+			case string:
+				if len(f) == 0 {
+					delete(v, field)
+				}
+			case map[string]interface{}:
+				if len(f) == 0 {
+					delete(v, field)
+				}
+			case []interface{}:
+				if len(f) == 0 {
+					delete(v, field)
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			clean(item)
+		}
+	}
+}
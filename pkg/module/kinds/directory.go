@@ -0,0 +1,513 @@
+package kinds
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/config/directory"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/api"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+	localdirectory "github.com/ibm-verify/verifyctl/pkg/module/directory"
+)
+
+const (
+	// scimPageSize is the number of users and groups fetched at a time.
+	scimPageSize = 1000
+
+	// groupExtension holds the description and the owners of a group.
+	groupExtension = "urn:ietf:params:scim:schemas:extension:ibm:2.0:Group"
+
+	// memberTypeGroup is the type of the members of a group that are groups.
+	memberTypeGroup = "group"
+)
+
+var attributeKind = &Kind{
+	Name:         "Attribute",
+	APIVersion:   "1.0",
+	Resource:     entitlements.ResourceAttributes,
+	Key:          "name",
+	ServerFields: []string{"id"},
+	properties:   "customProperties",
+	get: func(ctx context.Context, _ *config.AuthConfig, name string) (interface{}, error) {
+		// attributes can only be fetched by ID
+		attrs, _, err := directory.NewAttributeClient().GetAttributes(ctx, "", "", 0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, attr := range attrs.Attributes {
+			if attr.Name == name {
+				return attr, nil
+			}
+		}
+
+		return nil, errNotFound
+	},
+	list: func(ctx context.Context, _ *config.AuthConfig) ([]interface{}, error) {
+		attrs, _, err := directory.NewAttributeClient().GetAttributes(ctx, "", "", 0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		items := []interface{}{}
+		for _, attr := range attrs.Attributes {
+			items = append(items, attr)
+		}
+
+		return items, nil
+	},
+	create: func(ctx context.Context, _ *config.AuthConfig, data map[string]interface{}) (string, error) {
+		attr := &directory.Attribute{}
+		if err := fromMap(data, attr); err != nil {
+			return "", err
+		}
+
+		return directory.NewAttributeClient().CreateAttribute(ctx, attr)
+	},
+	update: func(ctx context.Context, _ *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}, _ []string) error {
+		attr := &directory.Attribute{}
+		if err := fromMap(desired, attr); err != nil {
+			return err
+		}
+
+		id, _ := live["id"].(string)
+		attr.ID = &id
+		return directory.NewAttributeClient().UpdateAttribute(ctx, attr)
+	},
+}
+
+var userKind = &Kind{
+	Name:          "User",
+	APIVersion:    "2.0",
+	Resource:      entitlements.ResourceUsers,
+	Key:           "userName",
+	ServerFields:  []string{"id", "meta"},
+	IgnoredFields: []string{"schemas"},
+	SecretFields:  []string{"password"},
+	get: func(ctx context.Context, _ *config.AuthConfig, name string) (interface{}, error) {
+		user, _, err := directory.NewUserClient().GetUser(ctx, name)
+		return user, fromSDKError(err)
+	},
+	list: func(ctx context.Context, auth *config.AuthConfig) ([]interface{}, error) {
+		return listSCIM(ctx, auth, "/v2.0/Users")
+	},
+	create: func(ctx context.Context, _ *config.AuthConfig, data map[string]interface{}) (string, error) {
+		user := &directory.User{}
+		if err := fromMap(data, user); err != nil {
+			return "", err
+		}
+
+		return directory.NewUserClient().CreateUser(ctx, user)
+	},
+	update: func(ctx context.Context, _ *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}, changed []string) error {
+		operations := []directory.UserPatchOperation{}
+		for _, field := range changed {
+			value := desired[field]
+			operations = append(operations, directory.UserPatchOperation{Op: "replace", Path: field, Value: &value})
+		}
+
+		userName, _ := live["userName"].(string)
+		return directory.NewUserClient().UpdateUser(ctx, userName, &operations)
+	},
+}
+
+var groupKind = &Kind{
+	Name:          "Group",
+	APIVersion:    "2.0",
+	Resource:      entitlements.ResourceGroups,
+	Key:           "displayName",
+	ServerFields:  []string{"id", "meta"},
+	IgnoredFields: []string{"schemas"},
+	get:           getGroup,
+	list: func(ctx context.Context, auth *config.AuthConfig) ([]interface{}, error) {
+		// the groups are fetched one by one, so that they include their members
+		groups, err := listSCIM(ctx, auth, "/v2.0/Groups")
+		if err != nil {
+			return nil, err
+		}
+
+		items := []interface{}{}
+		for _, g := range groups {
+			group, _ := g.(map[string]interface{})
+			displayName, _ := group["displayName"].(string)
+			item, err := getGroup(ctx, auth, displayName)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	},
+	create: func(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) (string, error) {
+		// the client resolves each member as a user, so the members that are groups and
+		// the owners are set once the group exists
+		initial := map[string]interface{}{}
+		for field, value := range data {
+			initial[field] = value
+		}
+
+		members, _ := data["members"].([]interface{})
+		userMembers := []interface{}{}
+		for _, m := range members {
+			if member, _ := m.(map[string]interface{}); !isGroupMember(member) {
+				userMembers = append(userMembers, m)
+			}
+		}
+
+		// the client expects a list of members
+		initial["members"] = userMembers
+		owners := groupOwners(data)
+		if len(owners) > 0 {
+			extension := map[string]interface{}{}
+			for field, value := range data[groupExtension].(map[string]interface{}) {
+				extension[field] = value
+			}
+
+			delete(extension, "owners")
+			initial[groupExtension] = extension
+		}
+
+		group := &directory.Group{}
+		if err := fromMap(initial, group); err != nil {
+			return "", err
+		}
+
+		client := directory.NewGroupClient()
+		uri, err := client.CreateGroup(ctx, group)
+		if err != nil || (len(userMembers) == len(members) && len(owners) == 0) {
+			return uri, err
+		}
+
+		if err := resolveGroup(ctx, auth, data); err != nil {
+			return "", err
+		}
+
+		operations := []directory.GroupPatchOperation{}
+		for _, field := range []string{"members", groupExtension} {
+			if value, ok := data[field]; ok {
+				operations = append(operations, directory.GroupPatchOperation{Op: "replace", Path: field, Value: &value})
+			}
+		}
+
+		displayName, _ := data["displayName"].(string)
+		return uri, client.UpdateGroup(ctx, displayName, &operations)
+	},
+	update: func(ctx context.Context, _ *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}, changed []string) error {
+		// the members are resolved to IDs and replaced as a whole
+		operations := []directory.GroupPatchOperation{}
+		for _, field := range changed {
+			value := desired[field]
+			operations = append(operations, directory.GroupPatchOperation{Op: "replace", Path: field, Value: &value})
+		}
+
+		displayName, _ := live["displayName"].(string)
+		return directory.NewGroupClient().UpdateGroup(ctx, displayName, &operations)
+	},
+	resolve: resolveGroup,
+	unresolve: func(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) error {
+		userNames := &resourceNames{path: "/v2.0/Users", key: "userName"}
+		groupNames := &resourceNames{path: "/v2.0/Groups", key: "displayName"}
+		if members, _ := data["members"].([]interface{}); len(members) > 0 {
+			named := []interface{}{}
+			for _, m := range members {
+				member, _ := m.(map[string]interface{})
+				id, _ := member["value"].(string)
+				if isGroupMember(member) {
+					displayName, err := groupNames.nameOf(ctx, auth, id, member["displayName"])
+					if err != nil {
+						return err
+					}
+
+					if len(displayName) == 0 {
+						return errorsx.G11NError("unable to find the displayName of the member group '%s'", id)
+					}
+
+					named = append(named, map[string]interface{}{"type": memberTypeGroup, "value": displayName})
+					continue
+				}
+
+				userName, err := userNames.nameOf(ctx, auth, id, member["userName"])
+				if err != nil {
+					return err
+				}
+
+				if len(userName) == 0 {
+					return errorsx.G11NError("unable to find the userName of the member '%s'", id)
+				}
+
+				named = append(named, map[string]interface{}{"value": userName})
+			}
+
+			data["members"] = named
+			sortMembers(data)
+		}
+
+		for _, owner := range groupOwners(data) {
+			id, _ := owner["value"].(string)
+			userName, err := userNames.nameOf(ctx, auth, id, nil)
+			if err != nil {
+				return err
+			}
+
+			if len(userName) == 0 {
+				return errorsx.G11NError("unable to find the userName of the owner '%s'", id)
+			}
+
+			// the other fields of an owner are set by the tenant
+			clear(owner)
+			owner["value"] = userName
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	// set here, as the members of a group may be groups
+	groupKind.references = groupReferences
+}
+
+var identitySourceKind = &Kind{
+	Name:         "IdentitySource",
+	APIVersion:   "2.0",
+	Resource:     entitlements.ResourceIdentitySources,
+	Key:          "instanceName",
+	ServerFields: []string{"id", "status"},
+	get: func(ctx context.Context, auth *config.AuthConfig, name string) (interface{}, error) {
+		identitySource, _, err := localdirectory.NewIdentitySourceClient().GetIdentitysource(ctx, auth, name)
+		return identitySource, fromSDKError(err)
+	},
+	list: func(ctx context.Context, auth *config.AuthConfig) ([]interface{}, error) {
+		identitySources, _, err := localdirectory.NewIdentitySourceClient().GetIdentitysources(ctx, auth, "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		items := []interface{}{}
+		for _, identitySource := range identitySources.IdentitySources {
+			items = append(items, identitySource)
+		}
+
+		return items, nil
+	},
+	create: func(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) (string, error) {
+		identitySource := &localdirectory.IdentitySource{}
+		if err := fromMap(data, identitySource); err != nil {
+			return "", err
+		}
+
+		return localdirectory.NewIdentitySourceClient().CreateIdentitysource(ctx, auth, identitySource)
+	},
+	update: func(ctx context.Context, auth *config.AuthConfig, _ map[string]interface{}, desired map[string]interface{}, _ []string) error {
+		identitySource := &localdirectory.IdentitySource{}
+		if err := fromMap(desired, identitySource); err != nil {
+			return err
+		}
+
+		return localdirectory.NewIdentitySourceClient().UpdateIdentitysource(ctx, auth, identitySource)
+	},
+}
+
+func getGroup(ctx context.Context, _ *config.AuthConfig, name string) (interface{}, error) {
+	group, _, err := directory.NewGroupClient().GetGroupByName(ctx, name)
+	if err != nil {
+		return nil, fromSDKError(err)
+	}
+
+	data, err := toMap(group)
+	if err != nil {
+		return nil, err
+	}
+
+	sortMembers(data)
+	return data, nil
+}
+
+// listSCIM returns the resources of every page of a SCIM list. The directory clients
+// only get the first page.
+func listSCIM(ctx context.Context, auth *config.AuthConfig, path string) ([]interface{}, error) {
+	response, page, err := api.NewAPIClient().Paginate(ctx, auth, &api.Request{
+		Method: http.MethodGet,
+		Path:   path + "?count=" + strconv.Itoa(scimPageSize),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if page == nil {
+		return nil, errorsx.G11NError("unable to list the resources; path=%s, code=%d, body=%s", path, response.StatusCode, string(response.Body))
+	}
+
+	items, _ := page["Resources"].([]interface{})
+	return items, nil
+}
+
+// resolveGroup replaces the names of the members and the owners of the group with their
+// IDs.
+func resolveGroup(ctx context.Context, _ *config.AuthConfig, desired map[string]interface{}) error {
+	// members are named by the userName of users and the displayName of groups
+	userClient := directory.NewUserClient()
+	groupClient := directory.NewGroupClient()
+	if members, _ := desired["members"].([]interface{}); len(members) > 0 {
+		resolved := []interface{}{}
+		for _, m := range members {
+			member, ok := m.(map[string]interface{})
+			if !ok {
+				return errorsx.G11NError("the members of a group must be objects with a 'value'")
+			}
+
+			name, _ := member["value"].(string)
+			if isGroupMember(member) {
+				id, err := groupClient.GetGroupId(ctx, name)
+				if err != nil {
+					return errorsx.G11NError("unable to resolve the member group '%s'; err=%v", name, err)
+				}
+
+				resolved = append(resolved, map[string]interface{}{"type": memberTypeGroup, "value": id})
+				continue
+			}
+
+			id, err := userClient.GetUserId(ctx, name)
+			if err != nil {
+				return errorsx.G11NError("unable to resolve the member '%s'; err=%v", name, err)
+			}
+
+			resolved = append(resolved, map[string]interface{}{"value": id})
+		}
+
+		desired["members"] = resolved
+		sortMembers(desired)
+	}
+
+	for _, owner := range groupOwners(desired) {
+		userName, _ := owner["value"].(string)
+		id, err := userClient.GetUserId(ctx, userName)
+		if err != nil {
+			return errorsx.G11NError("unable to resolve the owner '%s'; err=%v", userName, err)
+		}
+
+		owner["value"] = id
+	}
+
+	return nil
+}
+
+// resourceNames finds the names of users or groups by their ID. The resources are
+// listed the first time a name is not returned by the tenant.
+type resourceNames struct {
+	path  string
+	key   string
+	names map[string]string
+}
+
+// nameOf returns the name of the resource with the ID, or the returned name if it is
+// set.
+func (r *resourceNames) nameOf(ctx context.Context, auth *config.AuthConfig, id string, returned interface{}) (string, error) {
+	if name, _ := returned.(string); len(name) > 0 {
+		return name, nil
+	}
+
+	if r.names == nil {
+		resources, err := listSCIM(ctx, auth, r.path)
+		if err != nil {
+			return "", err
+		}
+
+		r.names = map[string]string{}
+		for _, res := range resources {
+			m, _ := res.(map[string]interface{})
+			resourceID, _ := m["id"].(string)
+			name, _ := m[r.key].(string)
+			r.names[resourceID] = name
+		}
+	}
+
+	return r.names[id], nil
+}
+
+// groupReferences returns the users and groups that are members of the group and the
+// users that own it.
+func groupReferences(data map[string]interface{}) []*Reference {
+	members, _ := data["members"].([]interface{})
+	result := []*Reference{}
+	for _, m := range members {
+		member, _ := m.(map[string]interface{})
+		name, _ := member["value"].(string)
+		if len(name) == 0 {
+			continue
+		}
+
+		if isGroupMember(member) {
+			result = append(result, &Reference{Kind: groupKind, Name: name})
+		} else {
+			result = append(result, &Reference{Kind: userKind, Name: name})
+		}
+	}
+
+	for _, owner := range groupOwners(data) {
+		if userName, _ := owner["value"].(string); len(userName) > 0 {
+			result = append(result, &Reference{Kind: userKind, Name: userName})
+		}
+	}
+
+	return result
+}
+
+// isGroupMember returns true if the member of a group is a group. Other members are
+// users.
+func isGroupMember(member map[string]interface{}) bool {
+	memberType, _ := member["type"].(string)
+	return strings.EqualFold(memberType, memberTypeGroup)
+}
+
+// groupOwners returns the owners of the group, which are users.
+func groupOwners(data map[string]interface{}) []map[string]interface{} {
+	extension, _ := data[groupExtension].(map[string]interface{})
+	owners, _ := extension["owners"].([]interface{})
+	result := []map[string]interface{}{}
+	for _, o := range owners {
+		if owner, ok := o.(map[string]interface{}); ok {
+			result = append(result, owner)
+		}
+	}
+
+	return result
+}
+
+// changedFields returns the desired fields that differ from the live resource, sorted
+// so that the operations are stable.
+func (k *Kind) changedFields(live map[string]interface{}, desired map[string]interface{}) []string {
+	fields := []string{}
+	for field, value := range desired {
+		if !k.ignored(field) && !contains(live[field], value) {
+			fields = append(fields, field)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+// sortMembers sorts the members of a group by their value, so that the order of the
+// members is not compared.
+func sortMembers(data map[string]interface{}) {
+	members, _ := data["members"].([]interface{})
+	sort.SliceStable(members, func(i, j int) bool {
+		return memberValue(members[i]) < memberValue(members[j])
+	})
+}
+
+func memberValue(member interface{}) string {
+	m, _ := member.(map[string]interface{})
+	value, _ := m["value"].(string)
+	return value
+}
@@ -0,0 +1,426 @@
+package kinds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/ibm-verify/verifyctl/pkg/config"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// Kind describes how the resources of a kind are found by their name, created and
+// updated. Resource data is handled as maps decoded from JSON, so that resources read
+// from files and fetched from the tenant can be compared.
+type Kind struct {
+	// Name is the kind without the type prefix, such as "User".
+	Name string
+
+	// APIVersion is the version set on the resource objects of the kind.
+	APIVersion string
+
+	// Resource is the name of the resource used to look up the entitlements.
+	Resource string
+
+	// Key is the field of the data that names the resource, such as "userName".
+	Key string
+
+	// ServerFields are set by the tenant. They are removed before the resource is
+	// written and are not compared.
+	ServerFields []string
+
+	// IgnoredFields are not compared.
+	IgnoredFields []string
+
+	// SecretFields are neither compared nor exported, such as passwords that are never
+	// returned.
+	SecretFields []string
+
+	// properties is the field of the data that holds the custom properties of the
+	// resource, which the tenant keeps as they are. The hash of the data last applied is
+	// stored there. It is empty if the resource has no such field.
+	properties string
+
+	get        func(ctx context.Context, auth *config.AuthConfig, name string) (interface{}, error)
+	list       func(ctx context.Context, auth *config.AuthConfig) ([]interface{}, error)
+	create     func(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) (string, error)
+	update     func(ctx context.Context, auth *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}, changed []string) error
+	resolve    func(ctx context.Context, auth *config.AuthConfig, desired map[string]interface{}) error
+	unresolve  func(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) error
+	references func(data map[string]interface{}) []*Reference
+}
+
+// Reference is a resource named in the data of another one, which must exist before
+// the other one is created or updated.
+type Reference struct {
+	Kind *Kind
+	Name string
+}
+
+// kinds is the registry of the kinds that can be applied. Resources that do not
+// reference each other are created in this order.
+var kinds = []*Kind{
+	attributeKind,
+	userKind,
+	groupKind,
+	identitySourceKind,
+	apiClientKind,
+	accessPolicyKind,
+}
+
+// LastAppliedProperty is the custom property of a resource that holds the hash of the
+// data last applied to it.
+const LastAppliedProperty = "verifyctl.lastApplied"
+
+// errNotFound is returned by the get functions of the kinds when no resource has the
+// name.
+var errNotFound = errors.New("resource not found")
+
+// sdkNotFoundRegExp matches the errors of the SDK clients that look up a resource by
+// name and find none, such as "no user found with userName jdoe".
+var sdkNotFoundRegExp = regexp.MustCompile(`^no .+ found with `)
+
+// All returns the kinds in the registry.
+func All() []*Kind {
+	return kinds
+}
+
+// ForKind returns the kind of a resource file. The kind is expected without the type
+// prefix.
+func ForKind(name string) (*Kind, bool) {
+	for _, k := range kinds {
+		if k.Name == name {
+			return k, true
+		}
+	}
+
+	return nil, false
+}
+
+// NameOf returns the name of the resource, read from the key field of the data.
+func (k *Kind) NameOf(data map[string]interface{}) string {
+	name, _ := data[k.Key].(string)
+	return name
+}
+
+// References returns the resources named in the data, such as the members of a group.
+func (k *Kind) References(data map[string]interface{}) []*Reference {
+	if k.references == nil {
+		return nil
+	}
+
+	return k.references(data)
+}
+
+// Get returns the data of the resource with the name, or nil if there is none.
+func (k *Kind) Get(ctx context.Context, auth *config.AuthConfig, name string) (map[string]interface{}, error) {
+	obj, err := k.get(ctx, auth, name)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return toMap(obj)
+}
+
+// fromSDKError returns errNotFound if the SDK client found no resource with the name,
+// or the error as is. The clients return neither a typed error nor the status of the
+// search, so the message is the only way to tell a missing resource from a failure.
+func fromSDKError(err error) error {
+	if err != nil && sdkNotFoundRegExp.MatchString(err.Error()) {
+		return errNotFound
+	}
+
+	return err
+}
+
+// List returns the data of every resource of the kind, sorted by name.
+func (k *Kind) List(ctx context.Context, auth *config.AuthConfig) ([]map[string]interface{}, error) {
+	objs, err := k.list(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		item, err := toMap(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return k.NameOf(items[i]) < k.NameOf(items[j])
+	})
+
+	return items, nil
+}
+
+// Export returns the data of a live resource as it is written to files, so that it
+// can be created with it. The fields set by the tenant, the secrets, the last applied
+// hash and the empty values are removed, and references to other resources by ID are replaced with their names.
+func (k *Kind) Export(ctx context.Context, auth *config.AuthConfig, live map[string]interface{}) (map[string]interface{}, error) {
+	data, err := k.writable(live)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range k.SecretFields {
+		delete(data, field)
+	}
+
+	k.withoutLastApplied(data)
+	clean(data)
+	if k.unresolve != nil {
+		if err := k.unresolve(ctx, auth, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// Create creates the resource and returns its URI.
+func (k *Kind) Create(ctx context.Context, auth *config.AuthConfig, data map[string]interface{}) (string, error) {
+	data, err := k.writable(data)
+	if err != nil {
+		return "", err
+	}
+
+	return k.create(ctx, auth, data)
+}
+
+// Update updates the live resource to match the desired data, if any field differs.
+// The desired data is expected to be resolved.
+func (k *Kind) Update(ctx context.Context, auth *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}) error {
+	changed := k.changedFields(live, desired)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	desired, err := k.writable(desired)
+	if err != nil {
+		return err
+	}
+
+	return k.update(ctx, auth, live, desired, changed)
+}
+
+// Resolve returns a copy of the desired data in the form returned by the tenant, so
+// that it can be compared with the live resource. References to other resources by
+// name, such as the members of a group, are replaced with their IDs.
+func (k *Kind) Resolve(ctx context.Context, auth *config.AuthConfig, desired map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := toMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.resolve != nil {
+		if err := k.resolve(ctx, auth, resolved); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// Differs returns true if any field of the resolved desired data differs from the live
+// resource. Fields that are not in the desired data are not compared.
+func (k *Kind) Differs(live map[string]interface{}, desired map[string]interface{}) bool {
+	return len(k.changedFields(live, desired)) > 0
+}
+
+// Hash returns a hash of the data without the server fields, which identifies the
+// content that was applied.
+func (k *Kind) Hash(data map[string]interface{}) (string, error) {
+	data, err := k.writable(data)
+	if err != nil {
+		return "", err
+	}
+
+	return hash(data)
+}
+
+// StoresLastApplied returns true if the hash of the data last applied can be stored on
+// the resources of the kind.
+func (k *Kind) StoresLastApplied() bool {
+	return len(k.properties) > 0
+}
+
+// LastApplied returns the hash of the data last applied to the live resource, or an
+// empty string if there is none.
+func (k *Kind) LastApplied(live map[string]interface{}) string {
+	properties, _ := live[k.properties].(map[string]interface{})
+	hash, _ := properties[LastAppliedProperty].(string)
+	return hash
+}
+
+// WithLastApplied returns a copy of the data with the hash set as its last applied
+// hash, if the kind can store it.
+func (k *Kind) WithLastApplied(data map[string]interface{}, hash string) (map[string]interface{}, error) {
+	m, err := toMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !k.StoresLastApplied() {
+		return m, nil
+	}
+
+	properties, _ := m[k.properties].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+
+	properties[LastAppliedProperty] = hash
+	m[k.properties] = properties
+	return m, nil
+}
+
+// withoutLastApplied removes the last applied hash from the data.
+func (k *Kind) withoutLastApplied(data map[string]interface{}) {
+	if properties, ok := data[k.properties].(map[string]interface{}); ok {
+		delete(properties, LastAppliedProperty)
+	}
+}
+
+func hash(data map[string]interface{}) (string, error) {
+	// maps are encoded with sorted keys
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func (k *Kind) ignored(field string) bool {
+	return field == k.Key || slices.Contains(k.ServerFields, field) || slices.Contains(k.IgnoredFields, field) || slices.Contains(k.SecretFields, field)
+}
+
+// writable returns a copy of the data without the server fields.
+func (k *Kind) writable(data map[string]interface{}) (map[string]interface{}, error) {
+	m, err := toMap(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range k.ServerFields {
+		delete(m, field)
+	}
+
+	return m, nil
+}
+
+// contains returns true if the live value has the fields and items of the desired
+// value. Fields that are not desired are not compared and empty values match missing
+// ones.
+func contains(live interface{}, desired interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live == nil && isEmpty(d)
+		}
+
+		for field, value := range d {
+			if !contains(l[field], value) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok {
+			return live == nil && len(d) == 0
+		}
+
+		if len(l) != len(d) {
+			return false
+		}
+
+		for i := range d {
+			if !contains(l[i], d[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch {
+	case live == nil:
+		return isEmpty(desired)
+	case desired == nil:
+		return isEmpty(live)
+	}
+
+	return reflect.DeepEqual(live, desired)
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(v) == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		for _, field := range v {
+			if !isEmpty(field) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// toMap converts a resource to a map through JSON, so that numbers are float64
+// whatever the source of the data.
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errorsx.G11NError("unable to encode the resource; err=%v", err)
+	}
+
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errorsx.G11NError("unable to decode the resource; err=%v", err)
+	}
+
+	return m, nil
+}
+
+// fromMap converts the data to the type used by the client of the kind.
+func fromMap(data map[string]interface{}, obj interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errorsx.G11NError("unable to encode the resource; err=%v", err)
+	}
+
+	if err := json.Unmarshal(b, obj); err != nil {
+		return errorsx.G11NError("unable to decode the resource; err=%v", err)
+	}
+
+	return nil
+}
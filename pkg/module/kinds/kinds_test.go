@@ -0,0 +1,304 @@
+package kinds
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    interface{}
+		desired interface{}
+		want    bool
+	}{
+		{
+			name:    "matches equal values",
+			live:    "Manager",
+			desired: "Manager",
+			want:    true,
+		},
+		{
+			name:    "reports different values",
+			live:    "Manager",
+			desired: "Intern",
+			want:    false,
+		},
+		{
+			name:    "matches an empty string with a missing value",
+			live:    nil,
+			desired: "",
+			want:    true,
+		},
+		{
+			name:    "matches false with a missing value",
+			live:    nil,
+			desired: false,
+			want:    true,
+		},
+		{
+			name:    "matches an empty list with a missing value",
+			live:    nil,
+			desired: []interface{}{},
+			want:    true,
+		},
+		{
+			name:    "matches an object of empty values with a missing value",
+			live:    nil,
+			desired: map[string]interface{}{"title": "", "emails": []interface{}{}},
+			want:    true,
+		},
+		{
+			name:    "matches a null with an empty value",
+			live:    "",
+			desired: nil,
+			want:    true,
+		},
+		{
+			name:    "reports a null desired for a value that is set",
+			live:    "Manager",
+			desired: nil,
+			want:    false,
+		},
+		{
+			name:    "reports a value that is missing",
+			live:    nil,
+			desired: "Manager",
+			want:    false,
+		},
+		{
+			name:    "reports zero for a missing value",
+			live:    nil,
+			desired: 0.0,
+			want:    false,
+		},
+		{
+			name:    "ignores the fields that are not desired",
+			live:    map[string]interface{}{"title": "Manager", "id": "123"},
+			desired: map[string]interface{}{"title": "Manager"},
+			want:    true,
+		},
+		{
+			name:    "matches empty fields with missing ones",
+			live:    map[string]interface{}{"title": "Manager"},
+			desired: map[string]interface{}{"title": "Manager", "nickName": "", "active": false, "emails": []interface{}{}},
+			want:    true,
+		},
+		{
+			name:    "reports a field that is missing",
+			live:    map[string]interface{}{"title": "Manager"},
+			desired: map[string]interface{}{"nickName": "bob"},
+			want:    false,
+		},
+		{
+			name:    "compares the items of lists in order",
+			live:    []interface{}{map[string]interface{}{"value": "a", "primary": true}, map[string]interface{}{"value": "b"}},
+			desired: []interface{}{map[string]interface{}{"value": "a"}, map[string]interface{}{"value": "b", "type": ""}},
+			want:    true,
+		},
+		{
+			name:    "reports lists of different lengths",
+			live:    []interface{}{"a", "b"},
+			desired: []interface{}{"a"},
+			want:    false,
+		},
+		{
+			name:    "reports lists in a different order",
+			live:    []interface{}{"a", "b"},
+			desired: []interface{}{"b", "a"},
+			want:    false,
+		},
+		{
+			name:    "reports an object where the tenant has a value",
+			live:    "Manager",
+			desired: map[string]interface{}{},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := contains(tt.live, tt.desired); got != tt.want {
+				t.Errorf("contains(%v, %v) = %v, want %v", tt.live, tt.desired, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		live    map[string]interface{}
+		desired map[string]interface{}
+		want    []string
+	}{
+		{
+			name:    "ignores the key, the server fields and empty values",
+			live:    map[string]interface{}{"userName": "bob", "id": "1", "title": "Manager"},
+			desired: map[string]interface{}{"userName": "bob", "id": "2", "title": "Manager", "nickName": ""},
+			want:    []string{},
+		},
+		{
+			name: "reports the paths of the fields that differ",
+			live: map[string]interface{}{
+				"userName": "bob",
+				"title":    "Manager",
+				"emails":   []interface{}{map[string]interface{}{"value": "bob@example.com", "type": "work"}},
+			},
+			desired: map[string]interface{}{
+				"userName": "bob",
+				"title":    "Intern",
+				"emails":   []interface{}{map[string]interface{}{"value": "bob@example.com", "type": "home"}},
+				"nickName": "bobby",
+			},
+			want: []string{"emails[0].type", "nickName", "title"},
+		},
+		{
+			name:    "reports a list of a different length as a whole",
+			live:    map[string]interface{}{"userName": "bob", "emails": []interface{}{map[string]interface{}{"value": "a"}}},
+			desired: map[string]interface{}{"userName": "bob", "emails": []interface{}{map[string]interface{}{"value": "a"}, map[string]interface{}{"value": "b"}}},
+			want:    []string{"emails"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, change := range userKind.Changes(tt.live, tt.desired) {
+				got = append(got, change.Path)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Changes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastApplied(t *testing.T) {
+	tests := []struct {
+		name string
+		kind *Kind
+		data map[string]interface{}
+
+		wantStored     bool
+		wantProperties interface{}
+	}{
+		{
+			name:           "stores the hash in the custom properties of an attribute",
+			kind:           attributeKind,
+			data:           map[string]interface{}{"name": "costCenter", "customProperties": map[string]interface{}{"team": "finance"}},
+			wantStored:     true,
+			wantProperties: map[string]interface{}{"team": "finance", LastAppliedProperty: "sha256:1"},
+		},
+		{
+			name:           "adds the properties of an API client",
+			kind:           apiClientKind,
+			data:           map[string]interface{}{"clientName": "pipeline"},
+			wantStored:     true,
+			wantProperties: map[string]interface{}{LastAppliedProperty: "sha256:1"},
+		},
+		{
+			name: "keeps the data of a kind without properties",
+			kind: userKind,
+			data: map[string]interface{}{"userName": "bob"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live, err := tt.kind.WithLastApplied(tt.data, "sha256:1")
+			if err != nil {
+				t.Fatalf("WithLastApplied() error = %v", err)
+			}
+
+			if got := tt.kind.LastApplied(live) == "sha256:1"; got != tt.wantStored {
+				t.Fatalf("LastApplied() = %q, want stored %t", tt.kind.LastApplied(live), tt.wantStored)
+			}
+
+			if !tt.wantStored {
+				if !reflect.DeepEqual(live, tt.data) {
+					t.Errorf("WithLastApplied() = %v, want %v", live, tt.data)
+				}
+
+				return
+			}
+
+			if got := live[tt.kind.properties]; !reflect.DeepEqual(got, tt.wantProperties) {
+				t.Errorf("WithLastApplied() properties = %v, want %v", got, tt.wantProperties)
+			}
+
+			properties, _ := tt.data[tt.kind.properties].(map[string]interface{})
+			if _, ok := properties[LastAppliedProperty]; ok {
+				t.Error("WithLastApplied() changed the data")
+			}
+
+			// the hash is not compared with the files
+			normalized, err := tt.kind.Normalize(live)
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+
+			if tt.kind.LastApplied(normalized) != "" {
+				t.Errorf("Normalize() = %v, want the hash removed", normalized)
+			}
+		})
+	}
+}
+
+func TestFromSDKError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantNotFound bool
+	}{
+		{
+			name: "keeps no error",
+		},
+		{
+			name:         "reports a missing user",
+			err:          errorsx.G11NError("no user found with userName %s", "jdoe"),
+			wantNotFound: true,
+		},
+		{
+			name:         "reports a missing group",
+			err:          errorsx.G11NError("no group found with group name %s", "admins"),
+			wantNotFound: true,
+		},
+		{
+			name:         "reports a missing API client",
+			err:          errorsx.G11NError("no API client found with exact clientName %s", "cli"),
+			wantNotFound: true,
+		},
+		{
+			name:         "reports a missing identity source",
+			err:          errorsx.G11NError("no identitysource found with identitysourceName %s", "ldap"),
+			wantNotFound: true,
+		},
+		{
+			name: "keeps a failed lookup",
+			err:  errorsx.G11NError("unable to get the User with userName %s; err=%s", "jdoe", "login again"),
+		},
+		{
+			name: "keeps an error that only mentions a missing resource",
+			err:  errorsx.G11NError("unable to resolve the member; err=no user found with userName jdoe"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fromSDKError(tt.err)
+			if errors.Is(got, errNotFound) != tt.wantNotFound {
+				t.Fatalf("fromSDKError(%v) = %v, want not found %t", tt.err, got, tt.wantNotFound)
+			}
+
+			if !tt.wantNotFound && got != tt.err {
+				t.Errorf("fromSDKError(%v) = %v, want the error as is", tt.err, got)
+			}
+		})
+	}
+}
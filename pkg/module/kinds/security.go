@@ -0,0 +1,119 @@
+package kinds
+
+import (
+	"context"
+
+	"github.com/ibm-verify/verify-sdk-go/pkg/config/security"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+	"github.com/ibm-verify/verifyctl/pkg/module/entitlements"
+)
+
+// pageSize is the number of API clients and access policies fetched at a time.
+const pageSize = 100
+
+var apiClientKind = &Kind{
+	Name:         "APIClient",
+	APIVersion:   "1.0",
+	Resource:     entitlements.ResourceAPIClients,
+	Key:          "clientName",
+	ServerFields: []string{"id", "clientId"},
+	SecretFields: []string{"clientSecret"},
+	properties:   "additionalProperties",
+	get: func(ctx context.Context, _ *config.AuthConfig, name string) (interface{}, error) {
+		apiClient, _, err := security.NewAPIClient().GetAPIClientByName(ctx, name)
+		return apiClient, fromSDKError(err)
+	},
+	list: func(ctx context.Context, _ *config.AuthConfig) ([]interface{}, error) {
+		client := security.NewAPIClient()
+		items := []interface{}{}
+		for page := 1; ; page++ {
+			apiClients, _, err := client.GetAPIClients(ctx, "", "", page, pageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			if apiClients.APIClients == nil {
+				return items, nil
+			}
+
+			for _, apiClient := range *apiClients.APIClients {
+				items = append(items, apiClient)
+			}
+
+			if len(*apiClients.APIClients) < pageSize {
+				return items, nil
+			}
+		}
+	},
+	create: func(ctx context.Context, _ *config.AuthConfig, data map[string]interface{}) (string, error) {
+		apiClient := &security.APIClientConfig{}
+		if err := fromMap(data, apiClient); err != nil {
+			return "", err
+		}
+
+		return security.NewAPIClient().CreateAPIClient(ctx, apiClient)
+	},
+	update: func(ctx context.Context, _ *config.AuthConfig, _ map[string]interface{}, desired map[string]interface{}, _ []string) error {
+		// the client is found by its name
+		apiClient := &security.APIClientConfig{}
+		if err := fromMap(desired, apiClient); err != nil {
+			return err
+		}
+
+		return security.NewAPIClient().UpdateAPIClient(ctx, apiClient)
+	},
+}
+
+var accessPolicyKind = &Kind{
+	Name:         "AccessPolicy",
+	APIVersion:   "5.0",
+	Resource:     entitlements.ResourceAccessPolicies,
+	Key:          "name",
+	ServerFields: []string{"id", "meta", "validations"},
+	get: func(ctx context.Context, _ *config.AuthConfig, name string) (interface{}, error) {
+		client := security.NewAccessPolicyClient()
+		id, err := client.GetAccessPolicyID(ctx, name)
+		if err != nil {
+			return nil, fromSDKError(err)
+		}
+
+		policy, _, err := client.GetAccessPolicy(ctx, id)
+		return policy, err
+	},
+	list: func(ctx context.Context, _ *config.AuthConfig) ([]interface{}, error) {
+		client := security.NewAccessPolicyClient()
+		items := []interface{}{}
+		for page := 1; ; page++ {
+			policies, _, err := client.GetAccessPolicies(ctx, page, pageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, policy := range policies.Policies {
+				items = append(items, policy)
+			}
+
+			if len(policies.Policies) < pageSize {
+				return items, nil
+			}
+		}
+	},
+	create: func(ctx context.Context, _ *config.AuthConfig, data map[string]interface{}) (string, error) {
+		policy := &security.Policy{}
+		if err := fromMap(data, policy); err != nil {
+			return "", err
+		}
+
+		return security.NewAccessPolicyClient().CreateAccessPolicy(ctx, policy)
+	},
+	update: func(ctx context.Context, _ *config.AuthConfig, live map[string]interface{}, desired map[string]interface{}, _ []string) error {
+		policy := &security.Policy{}
+		if err := fromMap(desired, policy); err != nil {
+			return err
+		}
+
+		id, _ := live["id"].(float64)
+		policy.ID = int(id)
+		return security.NewAccessPolicyClient().UpdateAccessPolicy(ctx, policy)
+	},
+}
@@ -141,7 +141,7 @@ func (c *LogsClient) printLogs(_ context.Context, w *tabwriter.Writer, logs []lo
 
 func (c *LogsClient) getLogs(ctx context.Context, auth *config.AuthConfig, logReq *logRequest) ([]log, error) {
 	vc := contextx.GetVerifyContext(ctx)
-	u, _ := url.Parse(fmt.Sprintf("https://%s/%s", auth.Tenant, apiLogsQuery))
+	u, _ := url.Parse(auth.TenantURL() + "/" + apiLogsQuery)
 
 	body, err := json.Marshal(logReq)
 	if err != nil {
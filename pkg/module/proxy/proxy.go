@@ -0,0 +1,261 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	contextx "github.com/ibm-verify/verify-sdk-go/pkg/core/context"
+	"github.com/ibm-verify/verifyctl/pkg/config"
+)
+
+// Options restrict the requests that are forwarded to the tenant.
+type Options struct {
+	// AllowedPrefixes are the path prefixes that can be requested. All paths are
+	// allowed if empty.
+	AllowedPrefixes []string
+
+	// AllowedMethods are the HTTP methods that can be used. All methods are allowed
+	// if empty.
+	AllowedMethods []string
+
+	// AllowedHosts are the values accepted in the Host header, which protects against
+	// DNS rebinding. Loopback names and addresses are always accepted.
+	AllowedHosts []string
+
+	// AllowedOrigins are the origins of browser pages that can use the proxy. Requests
+	// sent by browsers from other origins are rejected.
+	AllowedOrigins []string
+}
+
+// Proxy forwards requests to the tenant of the session with its access token. The
+// token is renewed when it expires or when the tenant rejects it.
+type Proxy struct {
+	ctx     context.Context
+	config  *config.CLIConfig
+	auth    *config.AuthConfig
+	options *Options
+	proxy   *httputil.ReverseProxy
+
+	mu    sync.Mutex
+	stale bool
+}
+
+func NewProxy(ctx context.Context, cliConfig *config.CLIConfig, auth *config.AuthConfig, options *Options) *Proxy {
+	if options == nil {
+		options = &Options{}
+	}
+
+	p := &Proxy{
+		ctx:     ctx,
+		config:  cliConfig,
+		auth:    auth,
+		options: options,
+	}
+
+	// requests are sent to the tenant, so that the server and the network settings of
+	// the session are applied by the transport
+	target := &url.URL{Scheme: "https", Host: auth.Tenant}
+	p.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.Out.Header.Del("Cookie")
+			r.Out.Header.Del("Origin")
+			r.Out.Header.Set("Authorization", "Bearer "+tokenFromContext(r.In.Context()))
+		},
+		ModifyResponse: func(res *http.Response) error {
+			if res.StatusCode == http.StatusUnauthorized {
+				// the token may have been revoked; get a new one for the next request
+				p.mu.Lock()
+				p.stale = true
+				p.mu.Unlock()
+			}
+
+			// the proxy decides which origins are allowed
+			res.Header.Del("Access-Control-Allow-Origin")
+			res.Header.Del("Access-Control-Allow-Credentials")
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			vc := contextx.GetVerifyContext(p.ctx)
+			vc.Logger.Errorf("unable to proxy the request; method=%s, path=%s, err=%v", r.Method, r.URL.Path, err)
+			writeError(w, http.StatusBadGateway, "unable to reach the tenant")
+		},
+	}
+
+	return p
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vc := contextx.GetVerifyContext(p.ctx)
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	if status, reason := p.check(r); status != 0 {
+		vc.Logger.Warnf("rejected request; method=%s, path=%s, status=%d, remote=%s, reason=%s", r.Method, r.URL.Path, status, r.RemoteAddr, reason)
+		writeError(w, status, reason)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodOptions && len(r.Header.Get("Access-Control-Request-Method")) > 0:
+		// CORS preflight requests are answered by the proxy
+		p.setCORSHeaders(rec, r)
+		rec.WriteHeader(http.StatusNoContent)
+	default:
+		token, err := p.token()
+		if err != nil {
+			vc.Logger.Errorf("unable to renew the token; err=%v", err)
+			writeError(rec, http.StatusBadGateway, "unable to renew the token. Login again.")
+			break
+		}
+
+		// the cleaned path is the one that was checked
+		r.URL.Path = path.Clean("/" + r.URL.Path)
+		r.URL.RawPath = ""
+		p.setCORSHeaders(rec, r)
+		p.proxy.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), tokenKey{}, token)))
+	}
+
+	vc.Logger.Infof("proxied request; method=%s, path=%s, query=%s, status=%d, duration=%s, remote=%s", r.Method, r.URL.Path, r.URL.RawQuery, rec.status, time.Since(start).Round(time.Millisecond), r.RemoteAddr)
+}
+
+// check returns the status code and the reason to reject the request, or zero if the
+// request is allowed.
+func (p *Proxy) check(r *http.Request) (int, string) {
+	if !p.allowedHost(r.Host) {
+		return http.StatusForbidden, "the host '" + r.Host + "' is not allowed"
+	}
+
+	if origin := r.Header.Get("Origin"); len(origin) > 0 && !slices.Contains(p.options.AllowedOrigins, origin) {
+		return http.StatusForbidden, "the origin '" + origin + "' is not allowed"
+	}
+
+	method := r.Method
+	if r.Method == http.MethodOptions {
+		if m := r.Header.Get("Access-Control-Request-Method"); len(m) > 0 {
+			method = m
+		}
+	}
+
+	if len(p.options.AllowedMethods) > 0 && !slices.ContainsFunc(p.options.AllowedMethods, func(m string) bool { return strings.EqualFold(m, method) }) {
+		return http.StatusMethodNotAllowed, "the method '" + method + "' is not allowed"
+	}
+
+	if !p.allowedPath(path.Clean("/" + r.URL.Path)) {
+		return http.StatusForbidden, "the path '" + r.URL.Path + "' is not allowed"
+	}
+
+	return 0, ""
+}
+
+func (p *Proxy) allowedPath(cleanPath string) bool {
+	if len(p.options.AllowedPrefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range p.options.AllowedPrefixes {
+		prefix = "/" + strings.Trim(prefix, "/")
+		if prefix == "/" || cleanPath == prefix || strings.HasPrefix(cleanPath, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Proxy) allowedHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	return slices.ContainsFunc(p.options.AllowedHosts, func(h string) bool { return strings.EqualFold(h, host) })
+}
+
+func (p *Proxy) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Add("Vary", "Origin")
+	if r.Method != http.MethodOptions {
+		return
+	}
+
+	methods := p.options.AllowedMethods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", strings.ToUpper(strings.Join(methods, ", ")))
+	if headers := r.Header.Get("Access-Control-Request-Headers"); len(headers) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+
+	w.Header().Set("Access-Control-Max-Age", "600")
+}
+
+// token returns the access token, renewing it first if it has expired or was rejected.
+func (p *Proxy) token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stale || p.auth.IsExpired() || len(p.auth.Token) == 0 {
+		if err := p.config.RenewAuth(p.ctx, p.auth); err != nil {
+			return "", err
+		}
+
+		p.stale = false
+	}
+
+	return p.auth.Token, nil
+}
+
+type tokenKey struct{}
+
+func tokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// OpenBrowser opens the URL in the default browser of the user. The command is
+// started without waiting for the browser to exit.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// reap the process in the background
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"archive/zip"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -15,16 +17,56 @@ import (
 const (
 	defaultDir  = ".verify"
 	defaultPerm = os.ModePerm
+
+	// the config directory and files hold credentials, so access is
+	// restricted to the owner
+	privateDirPerm  = 0700
+	privateFilePerm = 0600
 )
 
+// ExitError ends the command with the exit code, without printing the usage. It is
+// returned by commands whose exit code reports an outcome, such as a check that did
+// not pass. The error, if any, is written before exiting.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return "exit status " + strconv.Itoa(e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 func ExitOnError(cmd *cobra.Command, err error) {
+	ExitOnErrorWithCode(cmd, err, 1)
+}
+
+// ExitOnErrorWithCode writes the error and the usage, and exits with the code. An
+// ExitError exits with its own code instead, without the usage.
+func ExitOnErrorWithCode(cmd *cobra.Command, err error, code int) {
 	if err == nil {
 		return
 	}
 
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.Err != nil {
+			_, _ = io.WriteString(cmd.ErrOrStderr(), exitErr.Err.Error()+"\n")
+		}
+
+		os.Exit(exitErr.Code)
+	}
+
 	_, _ = io.WriteString(cmd.ErrOrStderr(), err.Error()+"\n")
 	_ = cmd.Usage()
-	os.Exit(1)
+	os.Exit(code)
 }
 
 func WriteString(cmd *cobra.Command, text string) {
@@ -56,13 +98,54 @@ func CreateOrGetDir() (string, error) {
 		return "", err
 	}
 
-	if err := os.MkdirAll(configDir, defaultPerm); err != nil {
+	if err := os.MkdirAll(configDir, privateDirPerm); err != nil {
+		return "", err
+	}
+
+	// directories created by older versions were accessible to all users
+	if err := os.Chmod(configDir, privateDirPerm); err != nil {
 		return "", err
 	}
 
 	return configDir, nil
 }
 
+// WritePrivateFile writes the data to a file that only the owner can read and write.
+// The data is written to a temporary file that then replaces the file, so readers
+// never see a partially written file.
+func WritePrivateFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := f.Name()
+	defer func() {
+		// no-op once the file is renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmpPath, privateFilePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
 func GetDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	lockTimeout      = 30 * time.Second
+	lockPollInterval = 50 * time.Millisecond
+)
+
+// LockFile takes an advisory lock on the file, creating it if needed, and waits for
+// other processes to release it. The lock is held until the returned function is
+// called. It is released automatically if the process exits.
+func LockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, privateFilePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		locked, err := tryLock(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+
+		if locked {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, errorsx.G11NError("Timed out waiting for the lock on '%s'. Another verifyctl process may be running.", path)
+		}
+
+		time.Sleep(lockPollInterval)
+	}
+
+	return func() {
+		_ = unlock(f)
+		_ = f.Close()
+	}, nil
+}
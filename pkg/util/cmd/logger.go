@@ -21,11 +21,16 @@ func NewLogger() (*logx.Logger, io.Writer, error) {
 	}
 
 	logFile := filepath.Join(path, fileName)
-	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, privateFilePerm)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if err := file.Chmod(privateFilePerm); err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
 	contextID := uuid.NewString()
 	level := slog.LevelInfo
 	switch logLevel := os.Getenv("LOG_LEVEL"); logLevel {
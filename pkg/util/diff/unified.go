@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type operation struct {
+	kind byte
+	line string
+
+	// aIndex and bIndex are the number of lines of a and b before the operation
+	aIndex int
+	bIndex int
+}
+
+// Unified returns the difference between the lines of a and b in the unified format,
+// with the number of unchanged lines around each change. An empty string is returned
+// if the lines are the same.
+func Unified(fromName string, toName string, a []string, b []string, context int) string {
+	ops := operations(a, b)
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+
+		// changes separated by up to twice the context are in the same hunk, as their
+		// context lines would otherwise be adjacent or overlap
+		start := max(0, i-context)
+		end := i + 1
+		for j := i + 1; j < len(ops) && j-end <= 2*context; j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			}
+		}
+
+		end = min(len(ops), end+context)
+		writeHunk(sb, ops[start:end])
+		i = end - 1
+	}
+
+	return sb.String()
+}
+
+// Lines splits the text into lines, without the final line break.
+func Lines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func writeHunk(sb *strings.Builder, ops []*operation) {
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+
+	aStart, bStart := ops[0].aIndex+1, ops[0].bIndex+1
+	if aCount == 0 {
+		aStart--
+	}
+
+	if bCount == 0 {
+		bStart--
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+	for _, op := range ops {
+		sb.WriteByte(op.kind)
+		sb.WriteString(op.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// operations returns the operations that turn a into b, based on their longest common
+// subsequence of lines.
+func operations(a []string, b []string) []*operation {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := []*operation{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, &operation{kind: ' ', line: a[i], aIndex: i, bIndex: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// removed lines are written before the added ones
+			ops = append(ops, &operation{kind: '-', line: a[i], aIndex: i, bIndex: j})
+			i++
+		default:
+			ops = append(ops, &operation{kind: '+', line: b[j], aIndex: i, bIndex: j})
+			j++
+		}
+	}
+
+	return ops
+}
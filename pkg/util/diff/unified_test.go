@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       string
+		b       string
+		context int
+		want    string
+	}{
+		{
+			name:    "returns nothing for the same lines",
+			a:       "1\n2\n3\n",
+			b:       "1\n2\n3\n",
+			context: 3,
+			want:    "",
+		},
+		{
+			name:    "limits the context around a change",
+			a:       "1\n2\n3\n4\n5\n6\n7\n",
+			b:       "1\n2\n3\nX\n5\n6\n7\n",
+			context: 1,
+			want:    "@@ -3,3 +3,3 @@\n 3\n-4\n+X\n 5\n",
+		},
+		{
+			name:    "stops the context at the first and last lines",
+			a:       "1\n2\n3\n",
+			b:       "X\n2\nY\n",
+			context: 3,
+			want:    "@@ -1,3 +1,3 @@\n-1\n+X\n 2\n-3\n+Y\n",
+		},
+		{
+			name:    "merges changes separated by twice the context",
+			a:       "1\n2\n3\n4\n5\n6\n",
+			b:       "1\nX\n3\n4\nY\n6\n",
+			context: 1,
+			want:    "@@ -1,6 +1,6 @@\n 1\n-2\n+X\n 3\n 4\n-5\n+Y\n 6\n",
+		},
+		{
+			name:    "splits changes separated by more than twice the context",
+			a:       "1\n2\n3\n4\n5\n6\n7\n",
+			b:       "1\nX\n3\n4\n5\nY\n7\n",
+			context: 1,
+			want:    "@@ -1,3 +1,3 @@\n 1\n-2\n+X\n 3\n@@ -5,3 +5,3 @@\n 5\n-6\n+Y\n 7\n",
+		},
+		{
+			name:    "starts an added file at zero",
+			a:       "",
+			b:       "a\nb\n",
+			context: 3,
+			want:    "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:    "starts a removed file at zero",
+			a:       "a\nb\n",
+			b:       "",
+			context: 3,
+			want:    "@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name:    "points to the line before a removal without context",
+			a:       "1\n2\n3\n4\n5\n",
+			b:       "1\n2\n4\n5\n",
+			context: 0,
+			want:    "@@ -3,1 +2,0 @@\n-3\n",
+		},
+		{
+			name:    "points to the line before an addition without context",
+			a:       "1\n2\n3\n4\n5\n",
+			b:       "1\n2\n3\nnew\n4\n5\n",
+			context: 0,
+			want:    "@@ -3,0 +4,1 @@\n+new\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if len(want) > 0 {
+				want = "--- a\n+++ b\n" + want
+			}
+
+			if got := Unified("a", "b", Lines(tt.a), Lines(tt.b), tt.context); got != want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 
 var (
 	defaultClient *http.Client = &http.Client{
-		Transport:     http.DefaultTransport,
+		Transport:     DefaultTransport,
 		Timeout:       30 * time.Minute,
 		CheckRedirect: noRedirects,
 	}
@@ -38,7 +38,9 @@ func (c *defaultClientx) Get(ctx context.Context, url *url.URL, headers http.Hea
 	}
 
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -74,7 +76,9 @@ func (c *defaultClientx) Post(ctx context.Context, url *url.URL, headers http.He
 	}
 
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -132,7 +136,9 @@ func (c *defaultClientx) PostMultipart(ctx context.Context, url *url.URL, header
 
 	request.Header.Add("content-type", "multipart/form-data")
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -168,7 +174,9 @@ func (c *defaultClientx) Put(ctx context.Context, url *url.URL, headers http.Hea
 	}
 
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -226,7 +234,9 @@ func (c *defaultClientx) PutMultipart(ctx context.Context, url *url.URL, headers
 
 	request.Header.Add("content-type", "multipart/form-data")
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -262,7 +272,9 @@ func (c *defaultClientx) Patch(ctx context.Context, url *url.URL, headers http.H
 	}
 
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
@@ -297,7 +309,9 @@ func (c *defaultClientx) Delete(ctx context.Context, url *url.URL, headers http.
 	}
 
 	for k, v := range headers {
-		request.Header.Add(k, v[0])
+		for _, value := range v {
+			request.Header.Add(k, value)
+		}
 	}
 
 	response, err := c.client.Do(request)
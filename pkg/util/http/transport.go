@@ -0,0 +1,309 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+// TransportOptions are the network settings used to reach a tenant.
+type TransportOptions struct {
+	// ProxyURL is the URL of the HTTP or HTTPS proxy. If it is empty, the proxy is
+	// taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+
+	// CAFile is the path to a PEM bundle of CA certificates that are trusted in
+	// addition to the system roots.
+	CAFile string
+
+	// ClientCertFile and ClientKeyFile are the paths to the PEM encoded certificate
+	// and private key presented for mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// TLSMinVersion is the minimum TLS version, either "1.2" or "1.3".
+	TLSMinVersion string
+
+	// Timeout limits the time taken by each request, including reading the body.
+	Timeout time.Duration
+}
+
+// DefaultTransport routes each request through the transport registered for its
+// host, or the standard transport if none is registered. It is installed as
+// http.DefaultTransport by InstallDefaultTransport, so that clients created by
+// dependencies, such as the SDK and OAuth 2.0 clients, also use it.
+var DefaultTransport = &hostTransport{
+	base: http.DefaultTransport,
+}
+
+// InstallDefaultTransport makes DefaultTransport the transport used by HTTP clients
+// that do not set one.
+func InstallDefaultTransport() {
+	if http.DefaultTransport != DefaultTransport {
+		DefaultTransport.base = http.DefaultTransport
+		http.DefaultTransport = DefaultTransport
+	}
+}
+
+// RegisterTransport routes requests for the host through the transport. The host may
+// include the port. A nil transport removes the registration.
+func RegisterTransport(host string, rt http.RoundTripper) {
+	DefaultTransport.register(strings.ToLower(host), rt)
+}
+
+// RegisterServer sends requests for the host to the server instead, such as a vanity
+// domain with a path prefix or a local server over plain HTTP. The scheme, host and
+// path prefix of the server replace those of the request URL. An empty server removes
+// the registration.
+func RegisterServer(host string, server string) error {
+	if len(server) == 0 {
+		DefaultTransport.registerServer(strings.ToLower(host), nil)
+		return nil
+	}
+
+	u, err := ParseServerURL(server)
+	if err != nil {
+		return err
+	}
+
+	DefaultTransport.registerServer(strings.ToLower(host), u)
+	return nil
+}
+
+// ResolveURL returns the URL that a request for the URL is sent to, which is on the
+// server registered for its host, if any. It is used for the URLs that are opened
+// outside of this process, such as in a browser.
+func ResolveURL(u *url.URL) *url.URL {
+	return DefaultTransport.resolve(u)
+}
+
+// ParseServerURL parses and validates the base URL of a server. The URL must be an
+// absolute HTTP or HTTPS URL without a query or fragment.
+func ParseServerURL(server string) (*url.URL, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, errorsx.G11NError("invalid server URL '%s'; err=%v", server, err)
+	}
+
+	if (u.Scheme != "https" && u.Scheme != "http") || len(u.Host) == 0 {
+		return nil, errorsx.G11NError("invalid server URL '%s'. The URL must start with 'https://' or 'http://'.", server)
+	}
+
+	if len(u.RawQuery) > 0 || len(u.Fragment) > 0 {
+		return nil, errorsx.G11NError("invalid server URL '%s'. The URL cannot include a query or fragment.", server)
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u, nil
+}
+
+// NewTransport builds a transport with the network settings.
+func NewTransport(opts *TransportOptions) (http.RoundTripper, error) {
+	transport := DefaultTransport.standardTransport().Clone()
+	if len(opts.ProxyURL) > 0 {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, errorsx.G11NError("invalid proxy URL '%s'; err=%v", opts.ProxyURL, err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{}
+	if transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+
+	if len(opts.CAFile) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		b, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errorsx.G11NError("no certificates found in the CA file '%s'.", opts.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(opts.ClientCertFile) > 0 || len(opts.ClientKeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, errorsx.G11NError("unable to load the client certificate; err=%v", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	switch opts.TLSMinVersion {
+	case "":
+	case "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, errorsx.G11NError("unsupported TLS version '%s'. Use '1.2' or '1.3'.", opts.TLSMinVersion)
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	if opts.Timeout <= 0 {
+		return transport, nil
+	}
+
+	return &timeoutTransport{
+		base:    transport,
+		timeout: opts.Timeout,
+	}, nil
+}
+
+type hostTransport struct {
+	base http.RoundTripper
+
+	mu      sync.RWMutex
+	routes  map[string]http.RoundTripper
+	servers map[string]*url.URL
+}
+
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Host)
+
+	t.mu.RLock()
+	rt, ok := t.routes[host]
+	if !ok {
+		rt, ok = t.routes[strings.ToLower(req.URL.Hostname())]
+	}
+	server := t.servers[host]
+	t.mu.RUnlock()
+
+	if server != nil && req.URL.Scheme == "https" {
+		r := req.Clone(req.Context())
+		r.URL = rewriteURL(req.URL, server)
+		r.Host = ""
+		req = r
+	}
+
+	if ok {
+		return rt.RoundTrip(req)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+func (t *hostTransport) register(host string, rt http.RoundTripper) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.routes == nil {
+		t.routes = map[string]http.RoundTripper{}
+	}
+
+	if rt == nil {
+		delete(t.routes, host)
+		return
+	}
+
+	t.routes[host] = rt
+}
+
+func (t *hostTransport) registerServer(host string, server *url.URL) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.servers == nil {
+		t.servers = map[string]*url.URL{}
+	}
+
+	if server == nil {
+		delete(t.servers, host)
+		return
+	}
+
+	t.servers[host] = server
+}
+
+// resolve returns the URL on the server registered for the host of the URL, or the
+// URL if there is none.
+func (t *hostTransport) resolve(u *url.URL) *url.URL {
+	t.mu.RLock()
+	server := t.servers[strings.ToLower(u.Host)]
+	t.mu.RUnlock()
+
+	if server == nil || u.Scheme != "https" {
+		return u
+	}
+
+	return rewriteURL(u, server)
+}
+
+// rewriteURL returns a copy of the URL on the server.
+func rewriteURL(u *url.URL, server *url.URL) *url.URL {
+	r := *u
+	r.Scheme = server.Scheme
+	r.Host = server.Host
+	r.Path = server.Path + u.Path
+	if len(u.RawPath) > 0 {
+		r.RawPath = server.EscapedPath() + u.RawPath
+	}
+
+	return &r
+}
+
+// standardTransport returns the transport that requests fall back to.
+func (t *hostTransport) standardTransport() *http.Transport {
+	if base, ok := t.base.(*http.Transport); ok {
+		return base
+	}
+
+	return &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:   true,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// timeoutTransport limits the time taken by each request. The deadline also
+// covers reading the response body.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	response, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
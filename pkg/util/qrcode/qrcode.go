@@ -0,0 +1,83 @@
+// Package qrcode encodes text as a QR code that can be printed on a terminal.
+package qrcode
+
+import (
+	"strings"
+
+	goqrcode "github.com/skip2/go-qrcode"
+
+	errorsx "github.com/ibm-verify/verify-sdk-go/pkg/core/errors"
+)
+
+const (
+	// quietZone is the number of light modules around the symbol.
+	quietZone = 2
+)
+
+// Code is an encoded QR code. Modules are addressed by row and column.
+type Code struct {
+	size    int
+	modules [][]bool
+}
+
+// Encode returns the smallest QR code that holds the text, with the error correction
+// level M.
+func Encode(text string) (*Code, error) {
+	q, err := goqrcode.New(text, goqrcode.Medium)
+	if err != nil {
+		return nil, errorsx.G11NError("unable to encode the text as a QR code; err=%v", err)
+	}
+
+	// the quiet zone is drawn by Terminal, narrower than the standard one
+	q.DisableBorder = true
+	modules := q.Bitmap()
+	return &Code{
+		size:    len(modules),
+		modules: modules,
+	}, nil
+}
+
+// Size returns the number of modules on each side of the symbol.
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark returns true if the module at the row and column is dark. Modules outside the
+// symbol are light.
+func (c *Code) Dark(row int, col int) bool {
+	if row < 0 || col < 0 || row >= c.size || col >= c.size {
+		return false
+	}
+
+	return c.modules[row][col]
+}
+
+// Terminal renders the code with block characters, two rows of modules per line.
+// Light modules are drawn as blocks so that the code reads correctly on terminals
+// with a dark background.
+func (c *Code) Terminal() string {
+	var sb strings.Builder
+	for row := -quietZone; row < c.size+quietZone; row += 2 {
+		for col := -quietZone; col < c.size+quietZone; col++ {
+			top, bottom := !c.Dark(row, col), !c.Dark(row+1, col)
+			if row+1 >= c.size+quietZone {
+				bottom = false
+			}
+
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}